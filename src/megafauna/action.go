@@ -0,0 +1,439 @@
+package megafauna
+
+import (
+	"errors"
+)
+
+// The engine implements a simplified turn structure.  On their turn, the active player takes exactly one
+// Action:
+//
+//   - Pass: take one gene from the bank.
+//   - BuyCard: buy a card from the lower display.  The card in position i costs i genes, one of which is put
+//     on each of the cheaper cards; the buyer also collects any genes on the card bought.  A mutation card
+//     adds its DNA to one of the buyer's species whose size is in the card's range, and a genotype card
+//     founds a new species with the card's silhouette.  If there's nothing the card can do for the buyer,
+//     it's bought for its genes alone.  The display then slides down, and a card is drawn from the current
+//     era's stack into the upper display; its event is resolved (see resolveEvent).
+//   - Populate: pay one gene to put an animal token of one of the buyer's species into a slot of a biome.
+//
+// When a card needs to be drawn and every era's stack is empty, the game ends with a final cull.
+
+// ActionType is the kind of an Action.
+type ActionType string
+
+const (
+	ActionPass     ActionType = "Pass"
+	ActionBuyCard  ActionType = "BuyCard"
+	ActionPopulate ActionType = "Populate"
+)
+
+// The slots of a Biome that an animal can be put in.
+const (
+	SlotHerbivore = "Herbivore"
+	SlotPredator  = "Predator"
+	SlotRooter    = "Rooter"
+)
+
+var (
+	ErrGameOver      = errors.New("The game is over.")
+	ErrNotYourTurn   = errors.New("It's not your turn.")
+	ErrIllegalAction = errors.New("Illegal action.")
+)
+
+// Action is one move by a player.  Which fields are used depends on Type.
+type Action struct {
+	Type       ActionType
+	Dentition  int    // the player taking the action
+	CardKey    string // BuyCard: the card being bought
+	Silhouette int    // BuyCard and Populate: the species; -1 if a card is bought for its genes alone
+	HabitatKey string // Populate: where the animal goes
+	Slot       string // Populate: which slot of the biome the animal goes in
+}
+
+// GetActivePlayer returns the Player whose turn it is, or nil if the game is over.
+func (g *Game) GetActivePlayer() *Player {
+	if g.IsOver || len(g.Players) == 0 {
+		return nil
+	}
+	return g.Players[g.ActivePlayer]
+}
+
+// LegalActions returns every Action that the player with the given dentition can take right now.  It returns
+// an empty slice if it's not that player's turn.
+func (g *Game) LegalActions(dentition int) []*Action {
+	actions := make([]*Action, 0)
+	p := g.GetActivePlayer()
+	if p == nil || p.Dentition != dentition {
+		return actions
+	}
+
+	actions = append(actions, &Action{Type: ActionPass, Dentition: dentition, Silhouette: -1})
+	actions = append(actions, g.legalBuyCardActions(p)...)
+	return append(actions, g.legalPopulateActions(p)...)
+}
+
+// legalBuyCardActions returns the BuyCard actions that p can take.
+func (g *Game) legalBuyCardActions(p *Player) []*Action {
+	actions := make([]*Action, 0)
+	for cost, key := range g.LowerDisplayCardKeys {
+		if cost > p.Genes {
+			break
+		}
+		card := g.Cards[key]
+		targets := make([]int, 0)
+		if card.Mutation != nil {
+			for s := range p.Genomes {
+				if p.HasSpecies(s) && p.SpeciesSizes[s] >= card.Mutation.MinSize && p.SpeciesSizes[s] <= card.Mutation.MaxSize {
+					targets = append(targets, s)
+				}
+			}
+		}
+		if card.Genotype != nil {
+			s := p.genotypeData(card).SilhouetteIndex
+			if !p.HasSpecies(s) {
+				targets = append(targets, s)
+			}
+		}
+		if len(targets) == 0 {
+			targets = append(targets, -1)
+		}
+		for _, s := range targets {
+			actions = append(actions, &Action{Type: ActionBuyCard, Dentition: p.Dentition, CardKey: key, Silhouette: s})
+		}
+	}
+	return actions
+}
+
+// legalPopulateActions returns the Populate actions that p can take.
+func (g *Game) legalPopulateActions(p *Player) []*Action {
+	actions := make([]*Action, 0)
+	if p.Genes < 1 {
+		return actions
+	}
+	for s, genome := range p.Genomes {
+		if genome == nil || p.AnimalTokens[s] == 0 {
+			continue
+		}
+		for _, b := range g.Board.Biomes() {
+			for _, slot := range []string{SlotHerbivore, SlotPredator, SlotRooter} {
+				if p.canPopulate(s, b, slot) {
+					actions = append(actions, &Action{Type: ActionPopulate, Dentition: p.Dentition, Silhouette: s, HabitatKey: b.Key, Slot: slot})
+				}
+			}
+		}
+	}
+	return actions
+}
+
+// canPopulate tells you whether p's species s can be put in the slot of biome b.  Sea biomes are only open to
+// marine species; herbivores and rooters must be able to feed in the biome; and a species can only have one
+// animal in each slot.
+func (p *Player) canPopulate(s int, b *Biome, slot string) bool {
+	genome := p.Genomes[s]
+	if b.IsSeaOnly() && genome.GetDNAValue("M") == 0 {
+		return false
+	}
+	data := b.Tile.BiomeData
+	var animals []*Animal
+	switch slot {
+	case SlotHerbivore:
+		if !genome.CanFeedOn(data.Requirements) {
+			return false
+		}
+		animals = b.Herbivore
+	case SlotRooter:
+		if data.RooterRequirements == nil || !genome.CanFeedOn(data.RooterRequirements) {
+			return false
+		}
+		animals = b.Rooter
+	case SlotPredator:
+		animals = b.Predator
+	default:
+		return false
+	}
+	for _, a := range animals {
+		if a.Dentition == p.Dentition && a.Silhouette == s {
+			return false
+		}
+	}
+	return true
+}
+
+// genotypeData returns the half of a genotype card that applies to p.
+func (p *Player) genotypeData(c *Card) *GenotypeCardData {
+	if p.IsDinosaur {
+		return c.Genotype.DinosaurData
+	}
+	return c.Genotype.MammalData
+}
+
+// Apply carries out an Action, if it's legal, and then passes the turn to the next player.
+func (g *Game) Apply(a *Action) error {
+	if g.IsOver {
+		return ErrGameOver
+	}
+	p := g.GetActivePlayer()
+	if a.Dentition != p.Dentition {
+		return ErrNotYourTurn
+	}
+	if a.Type == ActionBuyCard && g.Cards[a.CardKey] == nil {
+		return ErrCardNotFound
+	}
+	if !g.isLegal(a) {
+		return ErrIllegalAction
+	}
+
+	switch a.Type {
+	case ActionPass:
+		p.Genes++
+	case ActionBuyCard:
+		g.buyCard(p, a.CardKey, a.Silhouette)
+	case ActionPopulate:
+		g.populate(p, a.Silhouette, g.Board.HabitatMap[a.HabitatKey].Biome, a.Slot)
+	}
+
+	action := *a
+	g.Actions = append(g.Actions, &action)
+	g.Turn++
+	if !g.IsOver {
+		g.ActivePlayer = (g.ActivePlayer + 1) % len(g.Players)
+	}
+	return nil
+}
+
+// isLegal tells you whether a is one of the legal actions for its player.
+func (g *Game) isLegal(a *Action) bool {
+	for _, legal := range g.LegalActions(a.Dentition) {
+		if *legal == *a {
+			return true
+		}
+	}
+	return false
+}
+
+// buyCard buys the card with the given key from the lower display for p, and applies it to p's species s.
+func (g *Game) buyCard(p *Player, key string, s int) {
+	// pay for the card, and collect its genes
+	var cost int
+	for i, k := range g.LowerDisplayCardKeys {
+		if k == key {
+			cost = i
+			break
+		}
+		g.LowerDisplayGenes[i]++
+	}
+	p.Genes += g.LowerDisplayGenes[cost] - cost
+	p.CardKeys = append(p.CardKeys, key)
+	g.notify(&GameEvent{Type: EventCardBought, Dentition: p.Dentition, CardKey: key})
+
+	card := g.Cards[key]
+	if s >= 0 && card.Mutation != nil {
+		p.mutate(s, card.Mutation.Mutation)
+	}
+	if s >= 0 && card.Genotype != nil {
+		data := p.genotypeData(card)
+		p.Genomes[s] = MakeDNASpec(data.DNASpec.Spec)
+		p.SpeciesSizes[s] = data.MinSize
+	}
+
+	// slide the display down, and draw a new card into the upper display
+	g.LowerDisplayCardKeys = append(g.LowerDisplayCardKeys[:cost:cost], g.LowerDisplayCardKeys[cost+1:]...)
+	g.LowerDisplayGenes = append(g.LowerDisplayGenes[:cost:cost], g.LowerDisplayGenes[cost+1:]...)
+	if len(g.UpperDisplayCardKeys) > 0 {
+		g.LowerDisplayCardKeys = append(g.LowerDisplayCardKeys, g.UpperDisplayCardKeys[0])
+		g.LowerDisplayGenes = append(g.LowerDisplayGenes, 0)
+		g.UpperDisplayCardKeys = g.UpperDisplayCardKeys[1:]
+	}
+	g.drawCard()
+}
+
+// mutate adds DNA to p's species s, and to all of its animals on the board.
+func (p *Player) mutate(s int, dna *DNASpec) {
+	p.Genomes[s] = MakeDNASpec(p.Genomes[s].Spec + dna.Spec)
+	for _, a := range p.Species[s] {
+		a.Genome = p.Genomes[s]
+	}
+}
+
+// populate puts an animal token of p's species s into the slot of biome b.
+func (g *Game) populate(p *Player, s int, b *Biome, slot string) {
+	p.Genes--
+	p.AnimalTokens[s]--
+	a := &Animal{Dentition: p.Dentition, Size: p.SpeciesSizes[s], Genome: p.Genomes[s], Silhouette: s}
+	p.Species[s] = append(p.Species[s], a)
+	switch slot {
+	case SlotHerbivore:
+		b.Herbivore = append(b.Herbivore, a)
+	case SlotPredator:
+		b.Predator = append(b.Predator, a)
+	case SlotRooter:
+		b.Rooter = append(b.Rooter, a)
+	}
+	g.notifyAnimal(EventAnimalPlaced, b, slot, a)
+}
+
+// Names of the eras, which are also the names of the card stacks.
+const (
+	EraTriassic   = "Triassic"
+	EraJurassic   = "Jurassic"
+	EraCretaceous = "Cretaceous"
+	EraTertiary   = "Tertiary"
+)
+
+// eraStack returns the name and a pointer to the card stack of the current era, which is the first stack
+// that still has cards in it.  It returns "" and nil if every stack is empty.
+func (g *Game) eraStack() (string, *[]string) {
+	stacks := []struct {
+		name  string
+		stack *[]string
+	}{
+		{EraTriassic, &g.TriassicCardKeys},
+		{EraJurassic, &g.JurassicCardKeys},
+		{EraCretaceous, &g.CretaceousCardKeys},
+		{EraTertiary, &g.TertiaryCardKeys},
+	}
+	for _, s := range stacks {
+		if len(*s.stack) > 0 {
+			return s.name, s.stack
+		}
+	}
+	return "", nil
+}
+
+// Era returns the name of the current era, or "" once every era's stack has been drawn.
+func (g *Game) Era() string {
+	name, _ := g.eraStack()
+	return name
+}
+
+// drawCard draws a card from the current era's stack into the upper display and resolves its event.  If
+// there are no cards left, the game ends.
+func (g *Game) drawCard() {
+	era, stack := g.eraStack()
+	if stack == nil {
+		g.endGame()
+		return
+	}
+	key := (*stack)[0]
+	*stack = (*stack)[1:]
+	g.UpperDisplayCardKeys = append(g.UpperDisplayCardKeys, key)
+	g.notify(&GameEvent{Type: EventCardDrawn, CardKey: key})
+	g.resolveEvent(era, g.Cards[key].Event)
+}
+
+// resolveEvent carries out the event on a card newly drawn from the given era's stack.  Every card places a
+// tile from the era's tile stack (two, for draw-two events); warming and cooling change the climate; a Milankovich event culls the
+// latitudes on the card; and a catastrophe kills every animal of at least its level in size, then culls and
+// scores the whole board.
+func (g *Game) resolveEvent(era string, e *Event) {
+	tiles := 1
+	if e.IsDrawTwo {
+		tiles = 2
+	}
+	for i := 0; i < tiles; i++ {
+		g.placeNextTile(era)
+	}
+
+	if e.IsWarming {
+		g.changeClimate(1)
+	}
+	if e.IsCooling {
+		g.changeClimate(-1)
+	}
+	if e.IsMilankovich {
+		g.Cull(e.MilankovichLatitudeKeys...)
+	}
+	if e.IsCatastrophe {
+		onBoard := g.animalsOnBoard()
+		for _, b := range g.Board.Biomes() {
+			for _, a := range b.Animals() {
+				if a.Size >= e.CatastropheLevel {
+					g.kill(b, a)
+				}
+			}
+		}
+		g.cull(onBoard)
+		g.score()
+	}
+}
+
+// tileStack returns a pointer to the stack that tiles are drawn from for cards of the given era: the Mesozoic
+// tiles until the Tertiary, and the Cenozoic tiles after that.  If there aren't any Cenozoic tiles (the
+// standard tile data doesn't have them yet), the Mesozoic tiles are used in the Tertiary too.
+func (g *Game) tileStack(era string) *[]string {
+	if era == EraTertiary && len(g.CenozoicTileKeys) > 0 {
+		return &g.CenozoicTileKeys
+	}
+	return &g.MesozoicTileKeys
+}
+
+// placeNextTile draws a tile from the given era's tile stack and places it.  A biome tile goes on the board,
+// displacing any tile in its way to the tarpit; an immigrant tile becomes an animal in the biome with the
+// lowest climax number in its latitude, or goes to the tarpit if there are no biomes there.
+func (g *Game) placeNextTile(era string) {
+	stack := g.tileStack(era)
+	if len(*stack) == 0 {
+		return
+	}
+	t := g.Tiles[(*stack)[0]]
+	*stack = (*stack)[1:]
+
+	if t.IsBiomeTile() {
+		displaced, err := g.Board.PlaceTileOnBoard(t)
+		if err != nil {
+			g.TarpitTileKeys = append(g.TarpitTileKeys, t.Key)
+			return
+		}
+		habitat := g.Board.habitatOfTile(t)
+		if displaced != nil {
+			g.TarpitTileKeys = append(g.TarpitTileKeys, displaced.Key)
+			g.notify(&GameEvent{Type: EventTileDisplaced, TileKey: displaced.Key, HabitatKey: habitat.Key})
+		}
+		g.notify(&GameEvent{Type: EventTilePlaced, TileKey: t.Key, HabitatKey: habitat.Key})
+		if t.BiomeData.IsWarming {
+			g.changeClimate(1)
+		}
+		if t.BiomeData.IsCooling {
+			g.changeClimate(-1)
+		}
+		return
+	}
+
+	h := g.Board.FindLowestBiome(t.LatitudeKey)
+	if h == nil {
+		g.TarpitTileKeys = append(g.TarpitTileKeys, t.Key)
+		return
+	}
+	i := t.ImmigrantData
+	a := &Animal{Dentition: 1, Size: i.Size, Genome: i.DNA, ImmigrantTile: t}
+	if i.IsHerbivore {
+		h.Biome.Herbivore = append(h.Biome.Herbivore, a)
+		g.notifyAnimal(EventAnimalPlaced, h.Biome, SlotHerbivore, a)
+	} else {
+		h.Biome.Predator = append(h.Biome.Predator, a)
+		g.notifyAnimal(EventAnimalPlaced, h.Biome, SlotPredator, a)
+	}
+}
+
+// endGame runs the final cull, scores it, and ends the game.
+func (g *Game) endGame() {
+	g.Cull()
+	g.score()
+	g.IsOver = true
+	g.notify(&GameEvent{Type: EventGameOver, Dentition: g.Winner().Dentition})
+}
+
+// Winner returns the Player with the highest score, with ties going to the player with fewer teeth.  It
+// returns nil if the game isn't over.
+func (g *Game) Winner() *Player {
+	if !g.IsOver {
+		return nil
+	}
+	var winner *Player
+	for _, p := range g.Players {
+		if winner == nil || p.Score > winner.Score || (p.Score == winner.Score && p.Dentition < winner.Dentition) {
+			winner = p
+		}
+	}
+	return winner
+}
@@ -1,7 +1,6 @@
 package megafauna
 
 import (
-	"fmt"
 	"sort"
 )
 
@@ -54,45 +53,95 @@ func (r Reverse) Less(i, j int) bool {
 
 // FindWinner assigns scores to the animals in the contest, then sorts in reverse order to
 // find the winner.  It returns nil if there are no animals, or if none is suitable to
-// the Requirements.
+// the Requirements.  Use Resolve to find out how everyone scored, and why they lost.
 func (h *HerbivoreContest) FindWinner() *Animal {
+	return h.Resolve().Winner
+}
+
+// The reasons that an animal can be eliminated from a herbivore contest.
+const (
+	EliminatedUnsuitable = "Unsuitable" // it can't feed on the Requirements
+	EliminatedOutscored  = "Outscored"  // another suitable animal had a higher score
+)
+
+// ContestResult is the outcome of a HerbivoreContest: the winner, if there is one, and how every animal
+// scored.
+type ContestResult struct {
+	Winner  *Animal         // nil if no animal is suitable to the Requirements
+	Entries []*ContestEntry // one for each animal, highest score first
+}
+
+// ContestEntry is how one animal did in a HerbivoreContest.  Its Score is made up of its Suitability (100 if
+// it can feed on the Requirements, which it needs to survive), its Niche bonus (in the tens), and its
+// Dentition (the ones, which break ties in favor of more teeth).
+type ContestEntry struct {
+	Animal      *Animal
+	Suitability int
+	Niche       int
+	Dentition   int
+	Score       int
+	Eliminated  string // why the animal was eliminated (EliminatedUnsuitable or EliminatedOutscored); "" for the winner
+}
+
+// Resolve assigns scores to the animals in the contest, sorts them in reverse order of score,
+// and returns the winner and the breakdown of everyone's score.
+func (h *HerbivoreContest) Resolve() *ContestResult {
+	result := &ContestResult{Entries: make([]*ContestEntry, 0, len(h.Animals))}
 	if len(h.Animals) == 0 {
-		return nil
+		return result
 	}
 	h.Scores = make([]int, len(h.Animals))
 
 	// calculate the score for each animal
 	for i, animal := range h.Animals {
-		// suitability score is in the hundreds
-		if animal.Genome.CanFeedOn(h.Requirements) {
-			h.Scores[i] += 100
-		}
-		// niche score is in the tens
-		if h.Niche.Size {
-			h.Scores[i] += 10 * animal.Size
-		} else if h.Niche.Dentition != 0 {
-			if h.Niche.Dentition == animal.Dentition {
-				h.Scores[i] += 10
-			}
-		} else if h.Niche.DNA != "" {
-			_, ok := animal.Genome.Breakdown[h.Niche.DNA]
-			if ok {
-				h.Scores[i] += 10
-			}
-		}
-		// and the ones is the animal's dentition
-		h.Scores[i] += animal.Dentition
+		suitability, niche, dentition := h.scoreParts(animal)
+		h.Scores[i] = suitability + niche + dentition
 	}
 	sort.Sort(Reverse{h})
-	fmt.Printf("Scores are %v\n", h.Scores)
+
 	// an animal has to have a score of at least 100 to survive; among those, we pick
-	// the one with the highest score.
+	// the one with the highest score.  If nobody has, valar morghulis.
 	if h.Scores[0] >= 100 {
-		return h.Animals[0]
+		result.Winner = h.Animals[0]
+	}
+	for i, animal := range h.Animals {
+		e := &ContestEntry{Animal: animal, Score: h.Scores[i]}
+		e.Suitability, e.Niche, e.Dentition = h.scoreParts(animal)
+		switch {
+		case animal == result.Winner:
+		case e.Suitability == 0:
+			e.Eliminated = EliminatedUnsuitable
+		default:
+			e.Eliminated = EliminatedOutscored
+		}
+		result.Entries = append(result.Entries, e)
 	}
+	return result
+}
 
-	// nobody has a score of over 100; valar morghulis.
-	return nil
+// scoreParts returns the three parts of an animal's score in the contest: its suitability to the Requirements
+// (100 if it can feed on them, which it needs to survive), its niche bonus (in the tens), and its dentition.
+func (h *HerbivoreContest) scoreParts(animal *Animal) (suitability, niche, dentition int) {
+	// suitability score is in the hundreds
+	if animal.Genome.CanFeedOn(h.Requirements) {
+		suitability = 100
+	}
+	// niche score is in the tens
+	if h.Niche.Size {
+		niche = 10 * animal.Size
+	} else if h.Niche.Dentition != 0 {
+		if h.Niche.Dentition == animal.Dentition {
+			niche = 10
+		}
+	} else if h.Niche.DNA != "" {
+		_, ok := animal.Genome.Breakdown[h.Niche.DNA]
+		if ok {
+			niche = 10
+		}
+	}
+	// and the ones is the animal's dentition
+	dentition = animal.Dentition
+	return suitability, niche, dentition
 }
 
 // CarnivoreContest is used to determine the winner of carnivore contests during the cull.  Set Carnivores,
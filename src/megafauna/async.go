@@ -0,0 +1,122 @@
+package megafauna
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNoAutoAction = errors.New("The auto-action policy didn't choose a legal action.")
+
+// AutoAction chooses the action to take for a player who has run out of time.  It should return one of
+// g.LegalActions(dentition).
+type AutoAction func(g *Game, dentition int) *Action
+
+// AutoPass is an AutoAction that passes.
+func AutoPass(g *Game, dentition int) *Action {
+	return &Action{Type: ActionPass, Dentition: dentition, Silhouette: -1}
+}
+
+// AutoFirstAction is an AutoAction that buys the cheapest card the player can use: a mutation that fits one of
+// their species, or a genotype for a new species.  If there isn't one, it puts out an animal, or else passes.
+// It never buys a card just for its genes.
+func AutoFirstAction(g *Game, dentition int) *Action {
+	actions := g.LegalActions(dentition)
+	// LegalActions lists card purchases from the cheapest up
+	for _, a := range actions {
+		if a.Type == ActionBuyCard && a.Silhouette >= 0 {
+			return a
+		}
+	}
+	for _, a := range actions {
+		if a.Type == ActionPopulate {
+			return a
+		}
+	}
+	return AutoPass(g, dentition)
+}
+
+// Notifier is told about the things that players in an asynchronous game need to know about, so that it can
+// send them email or the like.
+type Notifier interface {
+	// TurnStarted is called when it becomes a player's turn, with the time that they have to move by.
+	TurnStarted(g *Game, p *Player, deadline time.Time)
+	// TurnTimedOut is called when a player runs out of time, with the action that was taken for them.
+	TurnTimedOut(g *Game, p *Player, a *Action)
+	// GameOver is called when the game ends.
+	GameOver(g *Game)
+}
+
+// AsyncGame plays a Game over a long time, play-by-mail style.  Each turn has a deadline; when a player misses
+// it, AutoAction moves for them.  The AsyncGame doesn't watch the clock itself: call Expire from time to time
+// (say, from a time.Ticker) to take the actions that are overdue.  An AsyncGame isn't safe for concurrent use.
+type AsyncGame struct {
+	Game       *Game
+	TurnLimit  time.Duration    // how long each player has to move
+	AutoAction AutoAction       // what to do for players who don't
+	Notifier   Notifier         // if not nil, told when turns start and time out, and when the game ends
+	Now        func() time.Time // the clock; time.Now unless a test says otherwise
+	Deadline   time.Time        // when the current turn times out
+}
+
+// NewAsyncGame starts asynchronous play of a Game, and notifies the first player that it's their turn.
+func NewAsyncGame(g *Game, turnLimit time.Duration, auto AutoAction, notifier Notifier) *AsyncGame {
+	return NewAsyncGameWithClock(g, turnLimit, auto, notifier, time.Now)
+}
+
+// NewAsyncGameWithClock is NewAsyncGame with a clock other than time.Now.
+func NewAsyncGameWithClock(g *Game, turnLimit time.Duration, auto AutoAction, notifier Notifier, now func() time.Time) *AsyncGame {
+	a := &AsyncGame{Game: g, TurnLimit: turnLimit, AutoAction: auto, Notifier: notifier, Now: now}
+	a.startTurn(now())
+	return a
+}
+
+// Apply applies a player's action to the game, and starts the next player's turn.
+func (a *AsyncGame) Apply(action *Action) error {
+	err := a.Game.Apply(action)
+	if err != nil {
+		return err
+	}
+	a.startTurn(a.Now())
+	return nil
+}
+
+// Expire takes the auto-action for every turn whose deadline has passed, and returns the actions it took.  If
+// several turns have timed out (because nobody called Expire for a while), each one's deadline is counted from
+// the one before, as if Expire had been called on time.
+func (a *AsyncGame) Expire() ([]*Action, error) {
+	taken := make([]*Action, 0)
+	now := a.Now()
+	for !a.Game.IsOver && now.After(a.Deadline) {
+		p := a.Game.GetActivePlayer()
+		action := a.AutoAction(a.Game, p.Dentition)
+		if action == nil || !a.Game.isLegal(action) {
+			return taken, ErrNoAutoAction
+		}
+		err := a.Game.Apply(action)
+		if err != nil {
+			return taken, err
+		}
+		taken = append(taken, action)
+		if a.Notifier != nil {
+			a.Notifier.TurnTimedOut(a.Game, p, action)
+		}
+		a.startTurn(a.Deadline)
+	}
+	return taken, nil
+}
+
+// startTurn sets the deadline for the active player's turn, counting from the given time, and notifies them;
+// or, if the game is over, says so.
+func (a *AsyncGame) startTurn(from time.Time) {
+	if a.Game.IsOver {
+		a.Deadline = time.Time{}
+		if a.Notifier != nil {
+			a.Notifier.GameOver(a.Game)
+		}
+		return
+	}
+	a.Deadline = from.Add(a.TurnLimit)
+	if a.Notifier != nil {
+		a.Notifier.TurnStarted(a.Game, a.Game.GetActivePlayer(), a.Deadline)
+	}
+}
@@ -8,7 +8,7 @@ package megafauna
 // if Tile is nil or Tile.BiomeTileData is nil.
 type Biome struct {
 	Key       string
-	Tile      *Tile // this will be either a biome or an immigrant
+	Tile      *Tile // the biome tile
 	Predator  []*Animal
 	Herbivore []*Animal
 	Rooter    []*Animal
@@ -19,3 +19,28 @@ type Biome struct {
 func (b *Biome) GetClimaxNumber() int {
 	return b.Tile.BiomeData.ClimaxNumber
 }
+
+// NewBiome creates an empty Biome for a biome tile placed in a habitat.
+func NewBiome(habitat *Habitat, t *Tile) *Biome {
+	b := new(Biome)
+	b.Key = habitat.Key
+	b.Tile = t
+	b.Habitat = habitat
+	b.Predator = make([]*Animal, 0)
+	b.Herbivore = make([]*Animal, 0)
+	b.Rooter = make([]*Animal, 0)
+	return b
+}
+
+// Animals returns all of the animals in the Biome: the predators, then the herbivores, then the rooters.
+func (b *Biome) Animals() []*Animal {
+	animals := make([]*Animal, 0, len(b.Predator)+len(b.Herbivore)+len(b.Rooter))
+	animals = append(animals, b.Predator...)
+	animals = append(animals, b.Herbivore...)
+	return append(animals, b.Rooter...)
+}
+
+// IsSeaOnly tells you whether the Biome is a sea biome, which only marine animals can live in.
+func (b *Biome) IsSeaOnly() bool {
+	return b.Tile.IsSea && !b.Tile.IsLand
+}
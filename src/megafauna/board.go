@@ -1,10 +1,15 @@
 package megafauna
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 )
 
+var ErrNotBiomeTile = errors.New("Not a biome tile.")
+
 const (
 	MapDirectionN = iota
 	MapDirectionE
@@ -12,9 +17,6 @@ const (
 	MapDirectionW
 )
 
-// latitudeKeys is local, because megafauna.LatitudeKeys includes the O for orogeny. 
-const latitudeKeys = "AJHT"
-
 // Board contains the Habitats on the board as well as various data structures to support lookup and searching.
 // LatitudeMap is used to find all the habitats with a given latitude key, and HabitatMap is used to find a
 // habitat given its key.
@@ -42,72 +44,152 @@ type Habitat struct {
 	Biome            *Biome     // The Biome, if any in this habitat
 }
 
-// NewBoard initializes the board.
-func NewBoard() *Board {
+// BoardFile is the name of the board layout source that Init and InitFS accept.
+const BoardFile = "board.csv"
+
+// HabitatLayout describes one Habitat in a BoardLayout.
+type HabitatLayout struct {
+	Key          string   // the habitat's key, e.g. "T0"
+	LatitudeKey  string   // the latitude row the habitat is in; one of LatitudeKeys other than O
+	ClimaxNumber int      // the printed climax number for the habitat
+	IsOrogeny    bool     // true for orogeny habitats
+	Adjacent     []string // keys of the adjacent habitats, indexed by map direction; "" if there's none
+}
+
+// BoardLayout is a Parseable description of a board.  The latitude rows of the board are in the order that
+// their latitudes first appear in the layout, and the habitats in each row are in the order they appear.
+type BoardLayout []*HabitatLayout
 
+// Indices into the CSV data for the board layout.
+const (
+	boardKeyField = iota
+	boardLatitudeKeyField
+	boardClimaxNumberField
+	boardIsOrogenyField
+	boardNorthField
+	boardEastField
+	boardSouthField
+	boardWestField
+	boardFieldCount
+)
+
+// Parse parses board layout data in CSV format from r and appends the habitats to the layout.
+func (layout *BoardLayout) Parse(r io.Reader) error {
+	return readRecords(r, BoardFile, boardFieldCount, nil, func(record []string) error {
+		var err error
+		h := new(HabitatLayout)
+		h.Key = record[boardKeyField]
+		if h.Key == "" {
+			return fieldError("Key", ErrMissingKey)
+		}
+		h.LatitudeKey = record[boardLatitudeKeyField]
+		if len(h.LatitudeKey) != 1 || h.LatitudeKey == "O" || !strings.Contains(LatitudeKeys, h.LatitudeKey) {
+			return fieldError("LatitudeKey", ErrInvalidLatitudeKey)
+		}
+		h.ClimaxNumber, err = strconv.Atoi(record[boardClimaxNumberField])
+		if err != nil {
+			return fieldError("ClimaxNumber", err)
+		}
+		h.IsOrogeny, err = strconv.ParseBool(record[boardIsOrogenyField])
+		if err != nil {
+			return fieldError("IsOrogeny", err)
+		}
+		h.Adjacent = make([]string, 4)
+		copy(h.Adjacent, record[boardNorthField:boardWestField+1])
+		*layout = append(*layout, h)
+		return nil
+	})
+}
+
+// NewBoard creates a Board from the layout.  It returns an error if the layout refers to habitats that
+// don't exist, or if its adjacencies aren't symmetric.
+func (layout BoardLayout) NewBoard() (*Board, error) {
 	board := new(Board)
-	board.Habitats = make([][]*Habitat, 4)
+	board.Habitats = make([][]*Habitat, 0)
 	board.LatitudeMap = make(map[string]*Latitude)
+	board.HabitatMap = make(map[string]*Habitat)
 
-	populateHabitats(board)
-	setOrogenyHabitats(board)
-	setAdjacentHabitats(board)
-	setLatitudeMap(board)
-	setHabitatMap(board)
+	names := make(LatitudeMap)
+	names.Parse(strings.NewReader(latitudeSourceData))
 
-	return board
-}
+	// create the habitats, and the latitudes that they're in
+	rows := make(map[string]int) // latitude key to row of board.Habitats
+	for index, hl := range layout {
+		if board.HabitatMap[hl.Key] != nil {
+			return nil, &DataError{File: BoardFile, Line: index + 1, Field: "Key", Err: fmt.Errorf("%w: %v", ErrDuplicateKey, hl.Key)}
+		}
+		h := new(Habitat)
+		h.Key = hl.Key
+		h.ClimaxNumber = hl.ClimaxNumber
+		h.IsOrogeny = hl.IsOrogeny
+		h.AdjacentHabitats = make([]*Habitat, 4)
+		board.HabitatMap[h.Key] = h
 
-// populateHabitats creates all the Habitat objects and stores them in the board's Habitats field.
-func populateHabitats(board *Board) {
-	climaxNumbers := make([]string, 4)
-	climaxNumbers[0] = "263541"
-	climaxNumbers[1] = "614532"
-	climaxNumbers[2] = "243561"
-	climaxNumbers[3] = "73856412"
+		row, ok := rows[hl.LatitudeKey]
+		if !ok {
+			lat := new(Latitude)
+			lat.Key = hl.LatitudeKey
+			if names[lat.Key] != nil {
+				lat.Name = names[lat.Key].Name
+			}
+			board.LatitudeMap[lat.Key] = lat
+			row = len(board.Habitats)
+			rows[lat.Key] = row
+			board.Habitats = append(board.Habitats, make([]*Habitat, 0))
+		}
+		board.Habitats[row] = append(board.Habitats[row], h)
+		board.LatitudeMap[hl.LatitudeKey].Habitats = board.Habitats[row]
+	}
 
-	for row, ch := range latitudeKeys {
-		latitudeKey := string(ch)
-		board.Habitats[row] = makeHabitatsInLatitude(latitudeKey, climaxNumbers[row])
+	// hook up the adjacent habitats
+	for index, hl := range layout {
+		h := board.HabitatMap[hl.Key]
+		for direction, key := range hl.Adjacent {
+			if key == "" {
+				continue
+			}
+			h.AdjacentHabitats[direction] = board.HabitatMap[key]
+			if h.AdjacentHabitats[direction] == nil {
+				return nil, &DataError{File: BoardFile, Line: index + 1, Field: mapDirectionNames[direction], Err: fmt.Errorf("No habitat with key %v.", key)}
+			}
+		}
 	}
-	return
+
+	setOrogenyHabitats(board)
+
+	err := board.CheckAdjacency()
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
 }
 
-// makeHabitatsInLatitude creates a slice of Habitats from a string of climax numbers.
-func makeHabitatsInLatitude(latitudeKey string, climaxNumbers string) []*Habitat {
-	lat := make([]*Habitat, len(climaxNumbers))
-	for col, ch := range climaxNumbers {
-		h := new(Habitat)
-		h.AdjacentHabitats = make([]*Habitat, 4)
-		h.ClimaxNumber, _ = strconv.Atoi(string(ch))
-		h.Key = fmt.Sprintf("%v%v", latitudeKey, col)
-		lat[col] = h
+// NewBoard initializes the standard board.
+func NewBoard() *Board {
+	layout := make(BoardLayout, 0)
+	err := layout.Parse(strings.NewReader(boardSourceData))
+	if err != nil {
+		panic(err)
 	}
-	return lat
+	board, err := layout.NewBoard()
+	if err != nil {
+		panic(err)
+	}
+	return board
 }
 
-// setOrogenyHabitats marks the Habitats on the board that are orogeny.  It also creates the LatitudeMap for orogeny habitats.
+// setOrogenyHabitats creates the LatitudeMap entry for orogeny habitats.
 func setOrogenyHabitats(board *Board) {
-	hab := board.Habitats
-	hab[0][4].IsOrogeny = true
-	hab[1][1].IsOrogeny = true
-	hab[1][3].IsOrogeny = true
-	hab[2][0].IsOrogeny = true
-	hab[2][4].IsOrogeny = true
-	hab[3][1].IsOrogeny = true
-
 	lat := new(Latitude)
 	lat.Key = "O"
 	lat.Name = "Orogeny"
-	lat.Habitats = make([]*Habitat, 6)
+	lat.Habitats = make([]*Habitat, 0)
 
-	index := 0
 	for row, _ := range board.Habitats {
 		for col, _ := range board.Habitats[row] {
 			h := board.Habitats[row][col]
 			if h.IsOrogeny {
-				lat.Habitats[index] = h
-				index++
+				lat.Habitats = append(lat.Habitats, h)
 			}
 		}
 	}
@@ -117,81 +199,68 @@ func setOrogenyHabitats(board *Board) {
 	return
 }
 
-// setAdjacentHabitats builds the adjacency array for each habitat on the board.
-func setAdjacentHabitats(board *Board) {
-	var h *Habitat
-	hab := board.Habitats
-
-	// for the top 4 rows of habitats, the method is simple, since the slices of habitats
-	// comprise a rectangular array of cells.
-	for row := 0; row < len(hab); row++ {
-		for col := 0; col < 6; col++ {
-			h = hab[row][col]
-			if row > 0 {
-				h.AdjacentHabitats[MapDirectionN] = hab[row-1][col]
-			}
-			if row < 3 {
-				h.AdjacentHabitats[MapDirectionS] = hab[row+1][col]
-			}
-			if col > 0 {
-				h.AdjacentHabitats[MapDirectionW] = hab[row][col-1]
-			}
-			if col < 5 {
-				h.AdjacentHabitats[MapDirectionE] = hab[row][col+1]
-			}
-		}
-	}
-
-	// the last two habitats in the Tropics are special, because they're actually in their own row on the board.
-	h = hab[3][6]
-	h.AdjacentHabitats[MapDirectionN] = hab[3][2]
-	h.AdjacentHabitats[MapDirectionE] = hab[3][7]
-	hab[3][2].AdjacentHabitats[MapDirectionS] = h
+// mapDirectionNames are the names of the map directions, indexed by direction.
+var mapDirectionNames = []string{"N", "E", "S", "W"}
 
-	h = hab[3][7]
-	h.AdjacentHabitats[MapDirectionW] = hab[3][6]
-	h.AdjacentHabitats[MapDirectionN] = hab[3][3]
-	hab[3][3].AdjacentHabitats[MapDirectionS] = h
-	return
+// oppositeDirection returns the map direction opposite to direction, e.g. MapDirectionS for MapDirectionN.
+func oppositeDirection(direction int) int {
+	return (direction + 2) % 4
 }
 
-// setLatitudeMap initializes the LatitudeMap part of the board.
-func setLatitudeMap(board *Board) {
-	m := board.LatitudeMap
-	for row, letter := range latitudeKeys {
-		key := string(letter)
-		lat := new(Latitude)
-		lat.Key = key
-		cols := 6
-		if key == "T" {
-			cols = 8
-		}
-		lat.Habitats = make([]*Habitat, cols)
-		for col := 0; col < cols; col++ {
-			lat.Habitats[col] = board.Habitats[row][col]
+// CheckAdjacency checks that the adjacencies on the board are symmetric: if B is north of A, then A must be
+// south of B, and so on.  It returns a ValidationReport of every habitat that breaks this rule, or nil.
+func (b *Board) CheckAdjacency() error {
+	var report ValidationReport
+	for _, row := range b.Habitats {
+		for _, h := range row {
+			for direction, other := range h.AdjacentHabitats {
+				if other == nil {
+					continue
+				}
+				opposite := oppositeDirection(direction)
+				if other.AdjacentHabitats[opposite] != h {
+					err := fmt.Errorf("%v is %v of %v, but %v isn't %v of %v.", other.Key, mapDirectionNames[direction], h.Key,
+						h.Key, mapDirectionNames[opposite], other.Key)
+					report.add(&DataError{File: BoardFile, Field: h.Key, Err: err})
+				}
+			}
 		}
-		m[key] = lat
 	}
-
-	m["A"].Name = "Arctic"
-	m["J"].Name = "Jet Stream"
-	m["H"].Name = "Horse Latitude"
-	m["T"].Name = "Tropics"
-
-	return
-}
-
-// setHabitatMap puts all of the Habitats on the board into a map keyed on Habitat.Key.
-func setHabitatMap(board *Board) {
-	board.HabitatMap = make(map[string]*Habitat)
-	for row, _ := range board.Habitats {
-		for col, _ := range board.Habitats[row] {
-			h := board.Habitats[row][col]
-			board.HabitatMap[h.Key] = h
-		}
+	if len(report) == 0 {
+		return nil
 	}
+	return report
 }
 
+// boardSourceData is the CSV layout of the standard board.  The last two habitats in the Tropics are in their
+// own row on the board, south of T2 and T3.
+const boardSourceData = `A0,A,2,FALSE,,A1,J0,
+A1,A,6,FALSE,,A2,J1,A0
+A2,A,3,FALSE,,A3,J2,A1
+A3,A,5,FALSE,,A4,J3,A2
+A4,A,4,TRUE,,A5,J4,A3
+A5,A,1,FALSE,,,J5,A4
+J0,J,6,FALSE,A0,J1,H0,
+J1,J,1,TRUE,A1,J2,H1,J0
+J2,J,4,FALSE,A2,J3,H2,J1
+J3,J,5,TRUE,A3,J4,H3,J2
+J4,J,3,FALSE,A4,J5,H4,J3
+J5,J,2,FALSE,A5,,H5,J4
+H0,H,2,TRUE,J0,H1,T0,
+H1,H,4,FALSE,J1,H2,T1,H0
+H2,H,3,FALSE,J2,H3,T2,H1
+H3,H,5,FALSE,J3,H4,T3,H2
+H4,H,6,TRUE,J4,H5,T4,H3
+H5,H,1,FALSE,J5,,T5,H4
+T0,T,7,FALSE,H0,T1,,
+T1,T,3,TRUE,H1,T2,,T0
+T2,T,8,FALSE,H2,T3,T6,T1
+T3,T,5,FALSE,H3,T4,T7,T2
+T4,T,6,FALSE,H4,T5,,T3
+T5,T,4,FALSE,H5,,,T4
+T6,T,1,FALSE,T2,T7,,
+T7,T,2,FALSE,T3,,,T6`
+
 // FindLowestClimax returns the habitat with the lowest climax number in the requested latitude.
 func (b *Board) FindLowestClimax(latitudeKey string) (*Habitat, error) {
 	var result *Habitat
@@ -213,18 +282,70 @@ func (b *Board) FindLowestClimax(latitudeKey string) (*Habitat, error) {
 	return result, nil
 }
 
-// PlaceTileOnBoard places a tile on the board, and can return a displaced tile.
+// PlaceTileOnBoard places a biome tile on the board, and can return a displaced tile.
 // PlaceTileOnBoard looks at the tile's Latitude, and then finds the Habitats for that Latitude, 
-// and among those chooses the one that has the lowest climax number.
+// and among those chooses the one that has the lowest climax number.  If that habitat already has a biome,
+// the new tile replaces the old one, which is returned; any animals in the biome stay where they are.
 func (b *Board) PlaceTileOnBoard(t *Tile) (*Tile, error) {
-	climax, err := b.FindLowestClimax(t.LatitudeKey)
-	if err == nil {
+	if !t.IsBiomeTile() {
+		return nil, ErrNotBiomeTile
+	}
+	habitat, err := b.FindLowestClimax(t.LatitudeKey)
+	if err != nil {
+		return nil, err
+	}
+	if habitat == nil {
 		return nil, ErrInvalidLatitudeKey
 	}
-	if t.IsBiomeTile() {
-		displacedTile := climax.Biome.Tile
-		// swap the existing biome for the new one
-		return displacedTile, nil
+	if habitat.Biome == nil {
+		habitat.Biome = NewBiome(habitat, t)
+		return nil, nil
+	}
+	displacedTile := habitat.Biome.Tile
+	habitat.Biome.Tile = t
+	return displacedTile, nil
+}
+
+// FindLowestBiome returns the habitat with a biome in it that has the lowest climax number in the requested
+// latitude, or nil if there are no biomes in the latitude.
+func (b *Board) FindLowestBiome(latitudeKey string) *Habitat {
+	var result *Habitat
+	lat := b.LatitudeMap[latitudeKey]
+	if lat == nil {
+		return nil
+	}
+	for _, h := range lat.Habitats {
+		if h.Biome == nil {
+			continue
+		}
+		if result == nil || h.Biome.GetClimaxNumber() < result.Biome.GetClimaxNumber() {
+			result = h
+		}
+	}
+	return result
+}
+
+// Biomes returns all of the Biomes on the board, in the order of Habitats.
+func (b *Board) Biomes() []*Biome {
+	biomes := make([]*Biome, 0)
+	for _, row := range b.Habitats {
+		for _, h := range row {
+			if h.Biome != nil {
+				biomes = append(biomes, h.Biome)
+			}
+		}
+	}
+	return biomes
+}
+
+// habitatOfTile returns the habitat that a biome tile is in, or nil if it isn't on the board.
+func (b *Board) habitatOfTile(t *Tile) *Habitat {
+	for _, row := range b.Habitats {
+		for _, h := range row {
+			if h.Biome != nil && h.Biome.Tile == t {
+				return h
+			}
+		}
 	}
-	return nil, nil
+	return nil
 }
@@ -0,0 +1,311 @@
+package megafauna
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// Bot is a computer player.  Everything a player decides in this engine (which card to buy, which species a
+// mutation goes to, where an animal goes) is part of an Action, so choosing an Action is the only decision a
+// Bot is asked to make.
+type Bot interface {
+	// ChooseAction chooses one of the legal actions, given the game as the bot's player can see it.
+	ChooseAction(view *GameView, legal []*Action) *Action
+}
+
+// PlayBots takes turns for the players that have a Bot, indexed by dentition, until it's the turn of a
+// player who doesn't (a human) or the game is over.  It returns the actions it took.
+func (g *Game) PlayBots(bots map[int]Bot) ([]*Action, error) {
+	taken := make([]*Action, 0)
+	for !g.IsOver {
+		p := g.GetActivePlayer()
+		bot := bots[p.Dentition]
+		if bot == nil {
+			break
+		}
+		action := bot.ChooseAction(g.ViewFor(p.Dentition), g.LegalActions(p.Dentition))
+		if action == nil {
+			return taken, ErrIllegalAction
+		}
+		err := g.Apply(action)
+		if err != nil {
+			return taken, err
+		}
+		taken = append(taken, action)
+	}
+	return taken, nil
+}
+
+// BotAutoAction makes an AutoAction out of a Bot, so that a bot can stand in for the players of an
+// AsyncGame who run out of time.
+func BotAutoAction(bot Bot) AutoAction {
+	return func(g *Game, dentition int) *Action {
+		return bot.ChooseAction(g.ViewFor(dentition), g.LegalActions(dentition))
+	}
+}
+
+// RandomBot is a Bot that chooses a legal action at random.
+type RandomBot struct {
+	Rand *rand.Rand
+}
+
+// NewRandomBot creates a RandomBot that draws from the given source of random numbers.
+func NewRandomBot(r *rand.Rand) *RandomBot {
+	return &RandomBot{Rand: r}
+}
+
+// ChooseAction chooses one of the legal actions at random.
+func (b *RandomBot) ChooseAction(view *GameView, legal []*Action) *Action {
+	if len(legal) == 0 {
+		return nil
+	}
+	return legal[b.Rand.Intn(len(legal))]
+}
+
+// GreedyBot is a Bot that chooses the action that would give its player the highest score if the whole board
+// were culled and scored right after it.  It tries each action on a game made from its view (see
+// NewGameFromView), so it has to be given the rules the game is played with.  Ties are broken at random.
+type GreedyBot struct {
+	Rules *RuleSet
+	Rand  *rand.Rand
+}
+
+// NewGreedyBot creates a GreedyBot for games played with a RuleSet, which draws from the given source of random
+// numbers.
+func NewGreedyBot(rules *RuleSet, r *rand.Rand) *GreedyBot {
+	return &GreedyBot{Rules: rules, Rand: r}
+}
+
+// ChooseAction chooses the legal action with the highest immediate score.  If no game can be made from the
+// view, it chooses at random.
+func (b *GreedyBot) ChooseAction(view *GameView, legal []*Action) *Action {
+	best := make([]*Action, 0)
+	bestScore := 0
+	for _, a := range legal {
+		score, err := b.immediateScore(view, a)
+		if err != nil {
+			continue
+		}
+		if len(best) == 0 || score > bestScore {
+			best = best[:0]
+			bestScore = score
+		}
+		if score == bestScore {
+			best = append(best, a)
+		}
+	}
+	if len(best) == 0 {
+		best = legal
+	}
+	if len(best) == 0 {
+		return nil
+	}
+	return best[b.Rand.Intn(len(best))]
+}
+
+// immediateScore returns the score that a's player would have if a were applied and then the whole board
+// culled and scored.
+func (b *GreedyBot) immediateScore(view *GameView, a *Action) (int, error) {
+	g, err := NewGameFromView(b.Rules, view, b.Rand)
+	if err != nil {
+		return 0, err
+	}
+	err = g.Apply(a)
+	if err != nil {
+		return 0, err
+	}
+	if !g.IsOver {
+		g.Cull()
+		g.score()
+	}
+	return g.GetPlayer(a.Dentition).Score, nil
+}
+
+// ErrInvalidView is returned by NewGameFromView when a GameView can't have come from a game with the given rules.
+var ErrInvalidView = errors.New("The view doesn't match the rules.")
+
+// NewGameFromView creates a Game that looks the way a GameView says it does, with the given rules.  Whatever the
+// view doesn't show (the order of the deck and of the card and tile stacks) is filled in at random from r: the
+// cards and tiles that aren't anywhere in the view are dealt out to the stacks, so that the game doesn't give
+// away what's coming.  This is how a Bot sees a game it can only see the view of.  The game has no Setup, so
+// it can't be cloned or replayed.
+func NewGameFromView(rules *RuleSet, view *GameView, r *rand.Rand) (*Game, error) {
+	var err error
+
+	g := new(Game)
+	g.Rules = rules
+	g.Board, err = rules.Board.NewBoard()
+	if err != nil {
+		return nil, err
+	}
+	g.Cards = make(map[string]*Card)
+	for k, c := range rules.Cards {
+		g.Cards[k] = c
+	}
+	g.Tiles = make(map[string]*Tile)
+	for k, t := range rules.Tiles {
+		g.Tiles[k] = t
+	}
+	g.Players = make(SortablePlayerCollection, 0, len(view.Players))
+	for _, pv := range view.Players {
+		p := playerFromView(rules, pv)
+		g.Tiles[p.HomelandTile.Key] = p.HomelandTile
+		if pv.Dentition == view.ActivePlayer {
+			g.ActivePlayer = len(g.Players)
+		}
+		g.Players = append(g.Players, p)
+	}
+
+	// the board, and the animals on it
+	seenTiles := make(map[string]bool)
+	for _, bv := range view.Biomes {
+		h, t := g.Board.HabitatMap[bv.HabitatKey], g.Tiles[bv.TileKey]
+		if h == nil {
+			return nil, ErrHabitatNotFound
+		}
+		if t == nil {
+			return nil, ErrTileNotFound
+		}
+		seenTiles[t.Key] = true
+		h.Biome = NewBiome(h, t)
+		slots := []struct {
+			animals *[]*Animal
+			views   []*AnimalView
+		}{{&h.Biome.Predator, bv.Predator}, {&h.Biome.Herbivore, bv.Herbivore}, {&h.Biome.Rooter, bv.Rooter}}
+		for _, slot := range slots {
+			for _, av := range slot.views {
+				a, err := g.animalFromView(av)
+				if err != nil {
+					return nil, err
+				}
+				if a.ImmigrantTile != nil {
+					seenTiles[a.ImmigrantTile.Key] = true
+				}
+				*slot.animals = append(*slot.animals, a)
+			}
+		}
+	}
+	for _, key := range view.Tarpit {
+		if g.Tiles[key] == nil {
+			return nil, ErrTileNotFound
+		}
+		seenTiles[key] = true
+	}
+	g.TarpitTileKeys = copyKeys(view.Tarpit)
+
+	// the displays
+	seenCards := make(map[string]bool)
+	g.UpperDisplayCardKeys = copyKeys(view.UpperDisplay)
+	for _, dv := range view.LowerDisplay {
+		g.LowerDisplayCardKeys = append(g.LowerDisplayCardKeys, dv.Key)
+		g.LowerDisplayGenes = append(g.LowerDisplayGenes, dv.Genes)
+	}
+	for _, keys := range [][]string{g.UpperDisplayCardKeys, g.LowerDisplayCardKeys} {
+		for _, key := range keys {
+			seenCards[key] = true
+		}
+	}
+	for _, p := range g.Players {
+		for _, key := range p.CardKeys {
+			seenCards[key] = true
+		}
+	}
+	for key := range seenCards {
+		if g.Cards[key] == nil {
+			return nil, ErrCardNotFound
+		}
+	}
+
+	// deal out the cards and tiles that nobody has seen; they're sorted first, so that the same r always deals
+	// them out the same way
+	unseenCards, mesozoic, cenozoic := make([]string, 0), make([]string, 0), make([]string, 0)
+	for key := range g.Cards {
+		if !seenCards[key] {
+			unseenCards = append(unseenCards, key)
+		}
+	}
+	for key, t := range g.Tiles {
+		switch {
+		case seenTiles[key] || t.HomelandPlayer != nil:
+		case t.IsMesozoic:
+			mesozoic = append(mesozoic, key)
+		default:
+			cenozoic = append(cenozoic, key)
+		}
+	}
+	if len(unseenCards) != view.DeckCards+view.TriassicCards+view.JurassicCards+view.CretaceousCards+view.TertiaryCards ||
+		len(mesozoic) != view.MesozoicTiles || len(cenozoic) != view.CenozoicTiles {
+		return nil, ErrInvalidView
+	}
+	for _, keys := range [][]string{unseenCards, mesozoic, cenozoic} {
+		sort.Strings(keys)
+		shuffleKeys(r, keys)
+	}
+	cardStacks := []struct {
+		stack *[]string
+		n     int
+	}{
+		{&g.CardKeys, view.DeckCards},
+		{&g.TriassicCardKeys, view.TriassicCards},
+		{&g.JurassicCardKeys, view.JurassicCards},
+		{&g.CretaceousCardKeys, view.CretaceousCards},
+		{&g.TertiaryCardKeys, view.TertiaryCards},
+	}
+	for _, s := range cardStacks {
+		*s.stack = unseenCards[:s.n:s.n]
+		unseenCards = unseenCards[s.n:]
+	}
+	g.MesozoicTileKeys = mesozoic
+	g.CenozoicTileKeys = cenozoic
+
+	g.Turn = view.Turn
+	g.Climate = view.Climate
+	g.IsOver = view.IsOver
+	g.Actions = make([]*Action, 0)
+	return g, nil
+}
+
+// playerFromView creates a Player from a PlayerView, without any animals; they're added from the board.
+func playerFromView(rules *RuleSet, pv *PlayerView) *Player {
+	p := NewPlayer(pv.Name, pv.Dentition)
+	p.Genes = pv.Genes
+	p.Score = pv.Score
+	p.CardKeys = copyKeys(pv.CardKeys)
+	p.AnimalTokens = append([]int{}, pv.AnimalTokens...)
+	p.InheritanceTiles = make([]*InheritanceTile, min(pv.InheritanceTiles, len(rules.InheritanceTiles)))
+	copy(p.InheritanceTiles, rules.InheritanceTiles)
+	for _, sv := range pv.Species {
+		p.Genomes[sv.Silhouette] = MakeDNASpec(sv.DNA)
+		p.SpeciesSizes[sv.Silhouette] = sv.Size
+	}
+	return p
+}
+
+// animalFromView creates an Animal from an AnimalView.  A player's animal shares its species' genome, and is
+// added to the species.
+func (g *Game) animalFromView(av *AnimalView) (*Animal, error) {
+	a := &Animal{Dentition: av.Dentition, Size: av.Size, Silhouette: av.Silhouette}
+	if av.ImmigrantKey != "" {
+		a.ImmigrantTile = g.Tiles[av.ImmigrantKey]
+		if a.ImmigrantTile == nil || a.ImmigrantTile.ImmigrantData == nil {
+			return nil, ErrTileNotFound
+		}
+		a.Genome = a.ImmigrantTile.ImmigrantData.DNA
+		return a, nil
+	}
+	p := g.GetPlayer(av.Dentition)
+	if p == nil || !p.HasSpecies(av.Silhouette) {
+		return nil, ErrInvalidView
+	}
+	a.Genome = p.Genomes[av.Silhouette]
+	p.Species[av.Silhouette] = append(p.Species[av.Silhouette], a)
+	return a, nil
+}
+
+// shuffleKeys shuffles a slice of keys with the given source of random numbers.
+func shuffleKeys(r *rand.Rand, keys []string) {
+	r.Shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+	})
+}
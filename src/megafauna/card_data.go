@@ -1,10 +1,10 @@
 package megafauna
 
 import (
-	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"strconv"
 	"strings"
 )
@@ -21,24 +21,46 @@ var (
 	ErrInvalidMinSize          = errors.New("Invalid minimum size.")
 	ErrInvalidMaxSize          = errors.New("Invalid maximum size.")
 	ErrInvalidSilhouetteAbbrev = errors.New("Invalid silhouette abbreviation.")
+	ErrInvalidSizeRange        = errors.New("Minimum size is greater than maximum size.")
+	ErrMissingKey              = errors.New("Missing key.")
+	ErrDuplicateKey            = errors.New("Duplicate key.")
 )
 
-// GetCards builds the master map of all Cards.
+// GetCards builds the master map of all Cards from the embedded card data.
 func GetCards() (map[string]*Card, error) {
+	return GetCardsFrom(strings.NewReader(mutationCardSourceData), strings.NewReader(genotypeCardSourceData))
+}
+
+// GetCardsFrom builds the master map of all Cards from CSV data in the same format as the embedded data.
+func GetCardsFrom(mutations io.Reader, genotypes io.Reader) (map[string]*Card, error) {
 	cards := make(map[string]*Card)
-	reader := strings.NewReader(mutationCardSourceData)
-	err := parseMutationCards(reader, cards)
+	err := parseMutationCards(mutations, cards, nil)
 	if err != nil {
 		return nil, err
 	}
-	reader = strings.NewReader(genotypeCardSourceData)
-	err = parseGenotypeCards(reader, cards)
+	err = parseGenotypeCards(genotypes, cards, nil)
 	if err != nil {
 		return nil, err
 	}
 	return cards, nil
 }
 
+// GetCardsFS builds the master map of all Cards from the MutationCardsFile and GenotypeCardsFile in fsys.
+// If either file doesn't exist, the embedded data is used in its place.
+func GetCardsFS(fsys fs.FS) (map[string]*Card, error) {
+	mutations, err := openDataFile(fsys, MutationCardsFile, mutationCardSourceData)
+	if err != nil {
+		return nil, err
+	}
+	defer mutations.Close()
+	genotypes, err := openDataFile(fsys, GenotypeCardsFile, genotypeCardSourceData)
+	if err != nil {
+		return nil, err
+	}
+	defer genotypes.Close()
+	return GetCardsFrom(mutations, genotypes)
+}
+
 // Indices into the CSV data for the mutation cards
 const (
 	mutationCardKeyField = iota
@@ -56,68 +78,66 @@ const (
 	mutationCardMilankovichLatitudesField
 )
 
-// parseMutationCards parses CSV data from the Reader and puts the resulting MutationCard objects in the
-// cards map.
-func parseMutationCards(r io.Reader, cards map[string]*Card) error {
-	csvReader := csv.NewReader(r)
-	csvReader.TrailingComma = true
-	records, err := csvReader.ReadAll()
-	if err != nil {
-		return err
-	}
-
-	fmterr := func(index int, err error) error {
-		return fmt.Errorf("Line %v: %v", index+1, err.Error())
-	}
-
-	for index, record := range records {
-		c := new(Card)
-		m := new(MutationCard)
-		c.Mutation = m
-
-		c.Key = record[mutationCardKeyField]
+// mutationCardFieldCount is the number of fields in each mutation card record.
+const mutationCardFieldCount = mutationCardMilankovichLatitudesField + 1
 
-		m.MinSize, err = strconv.Atoi(record[mutationCardMinSizeField])
-		if err != nil {
-			return fmterr(index, ErrInvalidMinSize)
-		}
-		m.MaxSize, err = strconv.Atoi(record[mutationCardMaxSizeField])
+// parseMutationCards parses CSV data from the Reader and puts the resulting MutationCard objects in the
+// cards map.  If report is nil, it stops at the first problem; otherwise it adds every problem to the report.
+func parseMutationCards(r io.Reader, cards map[string]*Card, report *ValidationReport) error {
+	return readRecords(r, MutationCardsFile, mutationCardFieldCount, report, func(record []string) error {
+		c, err := parseMutationCard(record)
 		if err != nil {
-			return fmterr(index, ErrInvalidMaxSize)
-		}
-		instinct := record[mutationCardInstinctField]
-		if instinct != "" {
-			if len(instinct) != 1 || !strings.Contains(InstinctKeys, instinct) {
-				return fmterr(index, ErrInvalidInstinctKey)
-			}
-			m.InstinctKey = instinct
-		}
-		m.Mutation = MakeDNASpec(record[mutationCardMutationField])
-		if m.Mutation == nil {
-			return fmterr(index, ErrInvalidDNASpec)
+			return err
 		}
+		return addCard(cards, c)
+	})
+}
 
-		m.Supertitle = record[mutationCardSupertitleField]
-		m.Title = record[mutationCardTitleField]
-		m.Subtitle = record[mutationCardSubtitleField]
+// parseMutationCard parses a single mutation card record.
+func parseMutationCard(record []string) (*Card, error) {
+	var err error
+	c := new(Card)
+	m := new(MutationCard)
+	c.Mutation = m
 
-		var catLevel int
-		if record[mutationCardCatastropheLevelField] != "" {
-			catLevel, err = strconv.Atoi(record[mutationCardCatastropheLevelField])
-			if err != nil {
-				return fmterr(index, ErrInvalidCatastropheLevel)
-			}
-		}
-		catWarming := (record[mutationCardCatastropheIsWarmingField] == "T")
-		c.Event, err = makeEvent(record[mutationCardEventTypeField], record[mutationCardMilankovichLatitudesField], catLevel, catWarming)
-		if err != nil {
-			return fmterr(index, err)
+	c.Key = record[mutationCardKeyField]
+	if c.Key == "" {
+		return nil, fieldError("Key", ErrMissingKey)
+	}
+
+	m.MinSize, err = strconv.Atoi(record[mutationCardMinSizeField])
+	if err != nil {
+		return nil, fieldError("MinSize", ErrInvalidMinSize)
+	}
+	m.MaxSize, err = strconv.Atoi(record[mutationCardMaxSizeField])
+	if err != nil {
+		return nil, fieldError("MaxSize", ErrInvalidMaxSize)
+	}
+	err = checkSizes(m.MinSize, m.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+	instinct := record[mutationCardInstinctField]
+	if instinct != "" {
+		if len(instinct) != 1 || !strings.Contains(InstinctKeys, instinct) {
+			return nil, fieldError("Instinct", ErrInvalidInstinctKey)
 		}
-		c.Event.Description = record[mutationCardEventDescriptionField]
+		m.InstinctKey = instinct
+	}
+	m.Mutation = MakeDNASpec(record[mutationCardMutationField])
+	if m.Mutation == nil {
+		return nil, fieldError("Mutation", ErrInvalidDNASpec)
+	}
+
+	m.Supertitle = record[mutationCardSupertitleField]
+	m.Title = record[mutationCardTitleField]
+	m.Subtitle = record[mutationCardSubtitleField]
 
-		cards[c.Key] = c
+	c.Event, err = parseEvent(record, mutationCardEventTypeField)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return c, nil
 }
 
 // Indices into the CSV data for the genotype cards
@@ -144,48 +164,65 @@ const (
 	genotypeCardMilankovichLatitudesField
 )
 
-// parseGenotypeCards parses CSV data from the Reader passed in into the receiver Card map
-func parseGenotypeCards(r io.Reader, cards map[string]*Card) error {
-	csvReader := csv.NewReader(r)
-	csvReader.TrailingComma = true
-	records, err := csvReader.ReadAll()
-	if err != nil {
-		return err
-	}
-	for _, record := range records {
-		var err error
-		var data *GenotypeCardData
-		c := new(Card)
-		c.Key = record[genotypeCardKeyField]
-
-		g := new(GenotypeCard)
-		c.Genotype = g
+// genotypeCardFieldCount is the number of fields in each genotype card record.
+const genotypeCardFieldCount = genotypeCardMilankovichLatitudesField + 1
 
-		data, err = parseGenotypeData(record, genotypeCardMSilhouetteField)
-		if err != nil {
-			return err
-		}
-		g.MammalData = data
-		data, err = parseGenotypeData(record, genotypeCardDSilhouetteField)
+// parseGenotypeCards parses CSV data from the Reader passed in into the receiver Card map.  If report is nil,
+// it stops at the first problem; otherwise it adds every problem to the report.
+func parseGenotypeCards(r io.Reader, cards map[string]*Card, report *ValidationReport) error {
+	return readRecords(r, GenotypeCardsFile, genotypeCardFieldCount, report, func(record []string) error {
+		c, err := parseGenotypeCard(record)
 		if err != nil {
 			return err
 		}
-		g.DinosaurData = data
+		return addCard(cards, c)
+	})
+}
 
-		var catLevel int
-		if record[genotypeCardCatastropheLevelField] != "" {
-			catLevel, err = strconv.Atoi(record[genotypeCardCatastropheLevelField])
-			if err != nil {
-				return ErrInvalidCatastropheLevel
-			}
-		}
-		catWarming := (record[genotypeCardCatastropheIsWarmingField] == "T")
-		c.Event, err = makeEvent(record[genotypeCardEventTypeField], record[genotypeCardMilankovichLatitudesField], catLevel, catWarming)
-		if err != nil {
-			return err
-		}
-		c.Event.Description = record[genotypeCardEventDescriptionField]
-		cards[c.Key] = c
+// parseGenotypeCard parses a single genotype card record.
+func parseGenotypeCard(record []string) (*Card, error) {
+	var err error
+	c := new(Card)
+	c.Key = record[genotypeCardKeyField]
+	if c.Key == "" {
+		return nil, fieldError("Key", ErrMissingKey)
+	}
+
+	g := new(GenotypeCard)
+	c.Genotype = g
+
+	g.MammalData, err = parseGenotypeData(record, genotypeCardMSilhouetteField)
+	if err != nil {
+		return nil, err
+	}
+	g.DinosaurData, err = parseGenotypeData(record, genotypeCardDSilhouetteField)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Event, err = parseEvent(record, genotypeCardEventTypeField)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// addCard adds a Card to the map, unless there's already a Card with the same key.
+func addCard(cards map[string]*Card, c *Card) error {
+	if cards[c.Key] != nil {
+		return fieldError("Key", fmt.Errorf("%w: %v", ErrDuplicateKey, c.Key))
+	}
+	cards[c.Key] = c
+	return nil
+}
+
+// checkSizes returns an error if the minimum and maximum sizes don't make sense together.
+func checkSizes(minSize int, maxSize int) error {
+	if minSize < 1 {
+		return fieldError("MinSize", ErrInvalidMinSize)
+	}
+	if maxSize < minSize {
+		return fieldError("MaxSize", ErrInvalidSizeRange)
 	}
 	return nil
 }
@@ -211,10 +248,15 @@ func parseGenotypeData(record []string, startField int) (*GenotypeCardData, erro
 	g := new(GenotypeCardData)
 
 	isDinosaur := !(startField == genotypeCardMSilhouetteField)
+	prefix := "Mammal"
+	if isDinosaur {
+		prefix = "Dinosaur"
+	}
+
 	silhouette, err = convertSilhouette(record[startField], isDinosaur)
 	startField++
 	if err != nil {
-		return nil, err
+		return nil, fieldError(prefix+"Silhouette", err)
 	}
 	g.SilhouetteIndex = silhouette
 
@@ -226,28 +268,75 @@ func parseGenotypeData(record []string, startField int) (*GenotypeCardData, erro
 	startField++
 	g.MinSize, err = strconv.Atoi(record[startField])
 	if err != nil {
-		return nil, ErrInvalidMinSize
+		return nil, fieldError(prefix+"MinSize", ErrInvalidMinSize)
 	}
 	startField++
 	g.MaxSize, err = strconv.Atoi(record[startField])
 	if err != nil {
-		return nil, ErrInvalidMaxSize
+		return nil, fieldError(prefix+"MaxSize", ErrInvalidMaxSize)
 	}
 	startField++
+	err = checkSizes(g.MinSize, g.MaxSize)
+	if err != nil {
+		e := err.(*DataError)
+		e.Field = prefix + e.Field
+		return nil, e
+	}
 	g.DNASpec = MakeDNASpec(record[startField])
 	if g.DNASpec == nil {
-		return nil, ErrInvalidDNASpec
+		return nil, fieldError(prefix+"DNASpec", ErrInvalidDNASpec)
 	}
 	startField++
 	return g, nil
 }
 
+// parseEvent parses the event fields of a card record, which are laid out the same way on mutation and
+// genotype cards, starting with the event type in startField.
+func parseEvent(record []string, startField int) (*Event, error) {
+	var err error
+	eventType := record[startField]
+	description := record[startField+1]
+	level := record[startField+2]
+	isWarming := record[startField+3]
+	milankovichLatitudes := record[startField+4]
+
+	var catLevel int
+	if level != "" {
+		catLevel, err = strconv.Atoi(level)
+		if err != nil {
+			return nil, fieldError("CatastropheLevel", ErrInvalidCatastropheLevel)
+		}
+	}
+	var catWarming bool
+	if isWarming != "" {
+		catWarming, err = strconv.ParseBool(isWarming)
+		if err != nil {
+			return nil, fieldError("CatastropheIsWarming", err)
+		}
+	}
+	e, err := makeEvent(eventType, milankovichLatitudes, catLevel, catWarming)
+	if err == ErrInvalidLatitudeKey {
+		return nil, fieldError("MilankovichLatitudes", err)
+	}
+	if err != nil {
+		return nil, fieldError("EventType", err)
+	}
+	if e.IsCatastrophe && e.CatastropheLevel < 1 {
+		return nil, fieldError("CatastropheLevel", ErrInvalidCatastropheLevel)
+	}
+	e.Description = description
+	return e, nil
+}
+
 // makeEvent creates an event from the appropriate fields in the card data.  Returns error if the data is invalid.
 func makeEvent(eventType string, milankovichLatitude string, catastropheLevel int, catastropheIsWarming bool) (*Event, error) {
 
 	var e Event
-	eventKey := eventType[0]
 	err := fmt.Errorf("\"%v\" is an invalid event type.", eventType)
+	if eventType == "" {
+		return nil, err
+	}
+	eventKey := eventType[0]
 
 	switch {
 	default:
@@ -0,0 +1,73 @@
+// Command megafauna-export converts the embedded card and tile data to the JSON or YAML data formats, writing
+// cards, tiles, inheritance_tiles and latitudes files to a directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"megafauna"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	format := flag.String("format", "json", "output format: json or yaml")
+	dir := flag.String("out", ".", "directory to write the data files to")
+	flag.Parse()
+
+	if *format != "json" && *format != "yaml" {
+		log.Fatalf("Unknown format %v.", *format)
+	}
+
+	cards, err := megafauna.GetCards()
+	if err != nil {
+		log.Fatal(err)
+	}
+	tiles, err := megafauna.GetTiles()
+	if err != nil {
+		log.Fatal(err)
+	}
+	inheritanceTiles := megafauna.GetInheritanceTiles()
+	latitudes := megafauna.LatitudeMap(megafauna.NewBoard().LatitudeMap)
+
+	files := []struct {
+		name      string
+		writeJSON func(w io.Writer) error
+		writeYAML func(w io.Writer) error
+	}{
+		{"cards",
+			func(w io.Writer) error { return megafauna.WriteCardsJSON(w, cards) },
+			func(w io.Writer) error { return megafauna.WriteCardsYAML(w, cards) }},
+		{"tiles",
+			func(w io.Writer) error { return megafauna.WriteTilesJSON(w, tiles) },
+			func(w io.Writer) error { return megafauna.WriteTilesYAML(w, tiles) }},
+		{"inheritance_tiles",
+			func(w io.Writer) error { return megafauna.WriteInheritanceTilesJSON(w, inheritanceTiles) },
+			func(w io.Writer) error { return megafauna.WriteInheritanceTilesYAML(w, inheritanceTiles) }},
+		{"latitudes",
+			func(w io.Writer) error { return megafauna.WriteLatitudesJSON(w, latitudes) },
+			func(w io.Writer) error { return megafauna.WriteLatitudesYAML(w, latitudes) }},
+	}
+
+	for _, file := range files {
+		path := filepath.Join(*dir, fmt.Sprintf("%v.%v", file.name, *format))
+		f, err := os.Create(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		write := file.writeJSON
+		if *format == "yaml" {
+			write = file.writeYAML
+		}
+		err = write(f)
+		if err == nil {
+			err = f.Close()
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(path)
+	}
+}
@@ -0,0 +1,37 @@
+// Command megafauna-server hosts megafauna games over HTTP.  See package megafauna/server for the API.  With
+// -data, games are saved in a directory and survive restarts.
+package main
+
+import (
+	"flag"
+	"log"
+	"megafauna"
+	"megafauna/server"
+	"megafauna/store"
+	"net/http"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dir := flag.String("data", "", "directory to save games in; if empty, games are kept only in memory")
+	flag.Parse()
+
+	s := server.New()
+	if *dir != "" {
+		rules, err := megafauna.Init(nil)
+		if err != nil {
+			log.Fatal(err)
+		}
+		st, err := store.NewFileStore(*dir, rules)
+		if err != nil {
+			log.Fatal(err)
+		}
+		s, err = server.NewWithStore(st)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	log.Printf("Listening on %v", *addr)
+	log.Fatal(http.ListenAndServe(*addr, s))
+}
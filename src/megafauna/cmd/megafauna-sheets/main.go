@@ -0,0 +1,88 @@
+// Command megafauna-sheets draws the cards and tiles as printable letter-size SVG sheets, at their real sizes,
+// for making prototype components.  By default it uses the embedded data; with -data, it reads the data files
+// from a directory, as megafauna.InitFS does.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"megafauna"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	data := flag.String("data", "", "directory to read the card and tile data from; if empty, the embedded data is used")
+	dir := flag.String("out", ".", "directory to write the sheets to")
+	flag.Parse()
+
+	var rules *megafauna.RuleSet
+	var err error
+	if *data == "" {
+		rules, err = megafauna.Init(nil)
+	} else {
+		rules, err = megafauna.InitFS(os.DirFS(*data))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cardKeys := make([]string, 0, len(rules.Cards))
+	for k := range rules.Cards {
+		cardKeys = append(cardKeys, k)
+	}
+	sort.Strings(cardKeys)
+	tileKeys := make([]string, 0, len(rules.Tiles))
+	for k := range rules.Tiles {
+		tileKeys = append(tileKeys, k)
+	}
+	sort.Strings(tileKeys)
+
+	for sheet := 0; sheet*megafauna.CardsPerSheet < len(cardKeys); sheet++ {
+		cards := make([]*megafauna.Card, 0)
+		for _, k := range page(cardKeys, sheet, megafauna.CardsPerSheet) {
+			cards = append(cards, rules.Cards[k])
+		}
+		writeSheet(*dir, fmt.Sprintf("cards-%02d.svg", sheet+1), func(w io.Writer) error {
+			return megafauna.RenderCardSheetSVG(cards, w)
+		})
+	}
+	for sheet := 0; sheet*megafauna.TilesPerSheet < len(tileKeys); sheet++ {
+		tiles := make([]*megafauna.Tile, 0)
+		for _, k := range page(tileKeys, sheet, megafauna.TilesPerSheet) {
+			tiles = append(tiles, rules.Tiles[k])
+		}
+		writeSheet(*dir, fmt.Sprintf("tiles-%02d.svg", sheet+1), func(w io.Writer) error {
+			return megafauna.RenderTileSheetSVG(tiles, w)
+		})
+	}
+}
+
+// page returns the keys that go on the given sheet.
+func page(keys []string, sheet, perSheet int) []string {
+	end := (sheet + 1) * perSheet
+	if end > len(keys) {
+		end = len(keys)
+	}
+	return keys[sheet*perSheet : end]
+}
+
+// writeSheet writes a sheet to a file.
+func writeSheet(dir, name string, render func(w io.Writer) error) {
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = render(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(path)
+}
@@ -0,0 +1,155 @@
+// Command megafauna-sim plays games between bots, for balance analysis.  It plays the games in parallel, each
+// with its own seed (the -seed flag plus the game's number), so a run can be repeated exactly, and reports
+// win rates by dentition and by side, the average number of species, how often each catastrophe card makes a
+// species extinct, how often each biome tile is placed, and how often each mutation and genotype card is
+// bought, for how much, how soon, and by whom (winners or losers), as CSV or JSON.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"megafauna"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+func main() {
+	games := flag.Int("games", 100, "number of games to play")
+	players := flag.Int("players", 4, "number of players in each game (2-4)")
+	bots := flag.String("bots", "random", "comma-separated bots for the seats, in order; the last one fills any other seats (random, greedy or mcts)")
+	iterations := flag.Int("iterations", 100, "iterations per decision for mcts bots")
+	seed := flag.Int64("seed", 1, "seed of the first game; game n uses seed+n")
+	parallel := flag.Int("parallel", runtime.NumCPU(), "number of games to play at once")
+	format := flag.String("format", "csv", "output format (csv or json)")
+	out := flag.String("out", "", "file to write the report to; if empty, standard output")
+	data := flag.String("data", "", "directory to read the card and tile data from; if empty, the embedded data is used")
+	flag.Parse()
+
+	if *players < 2 || *players > 4 || *games < 1 || *parallel < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	botNames := strings.Split(*bots, ",")
+	for _, name := range botNames {
+		if name != "random" && name != "greedy" && name != "mcts" {
+			log.Fatalf("unknown bot %q", name)
+		}
+	}
+	var write func(w io.Writer, r *Report) error
+	switch *format {
+	case "csv":
+		write = writeCSV
+	case "json":
+		write = writeJSON
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	var rules *megafauna.RuleSet
+	var err error
+	if *data == "" {
+		rules, err = megafauna.Init(nil)
+	} else {
+		rules, err = megafauna.InitFS(os.DirFS(*data))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// play the games, keeping their records in order so that the report doesn't depend on the timing
+	records := make([]*gameRecord, *games)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < *parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range next {
+				rec, err := playGame(rules, *seed+int64(n), *players, botNames, *iterations)
+				if err != nil {
+					log.Fatalf("game %v: %v", n, err)
+				}
+				records[n] = rec
+			}
+		}()
+	}
+	for n := 0; n < *games; n++ {
+		next <- n
+	}
+	close(next)
+	wg.Wait()
+
+	r := newReport(rules, records)
+	if *out == "" {
+		if err := write(os.Stdout, r); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = write(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// playGame plays one game between bots, with seats at random dentitions, and records what happened.
+func playGame(rules *megafauna.RuleSet, seed int64, players int, botNames []string, iterations int) (*gameRecord, error) {
+	r := rand.New(rand.NewSource(seed))
+	dentitions := r.Perm(4)
+	seats := make([]megafauna.Seat, players)
+	for i := range seats {
+		seats[i] = megafauna.Seat{Name: fmt.Sprintf("Bot %v", i+1), Dentition: dentitions[i] + 2}
+	}
+	g, err := megafauna.NewGameWithSeed(rules, seats, seed)
+	if err != nil {
+		return nil, err
+	}
+	bots := make(map[int]megafauna.Bot)
+	for i, seat := range seats {
+		name := botNames[len(botNames)-1]
+		if i < len(botNames) {
+			name = botNames[i]
+		}
+		bots[seat.Dentition] = newBot(name, rules, r, iterations)
+	}
+
+	rec := newGameRecord(g)
+	g.AddObserver(rec)
+	for !g.IsOver {
+		p := g.GetActivePlayer()
+		a := bots[p.Dentition].ChooseAction(g.ViewFor(p.Dentition), g.LegalActions(p.Dentition))
+		if a == nil {
+			return nil, megafauna.ErrIllegalAction
+		}
+		before := snapshot(g)
+		if err := g.Apply(a); err != nil {
+			return nil, err
+		}
+		rec.record(g, a, before)
+	}
+	rec.finish(g)
+	return rec, nil
+}
+
+// newBot creates a bot by name.
+func newBot(name string, rules *megafauna.RuleSet, r *rand.Rand, iterations int) megafauna.Bot {
+	switch name {
+	case "greedy":
+		return megafauna.NewGreedyBot(rules, r)
+	case "mcts":
+		return megafauna.NewMCTSBot(rules, r, iterations, 0)
+	}
+	return megafauna.NewRandomBot(r)
+}
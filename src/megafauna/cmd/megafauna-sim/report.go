@@ -0,0 +1,362 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"megafauna"
+	"sort"
+)
+
+// snapshotState is what playGame remembers about a game before each action, to see what the action did.
+type snapshotState struct {
+	display []string // the keys of the cards in the lower display
+}
+
+// snapshot remembers the state of a game.
+func snapshot(g *megafauna.Game) *snapshotState {
+	return &snapshotState{display: append([]string{}, g.LowerDisplayCardKeys...)}
+}
+
+// gameRecord is what happened in one game.  It observes the game, to count the tiles placed, and to see which
+// cards are drawn and how many species go extinct during each action.
+type gameRecord struct {
+	dentitions   []int
+	winner       int
+	species      map[int]int    // the number of living species at the end, by dentition
+	catastrophes []catastrophe  // the catastrophe cards drawn, in order
+	tilesPlaced  map[string]int // the number of times each biome tile was placed, by tile key
+	offered      map[string]int // the turn on which each card came into the lower display, by card key
+	purchases    []purchase     // the cards bought, in order
+	drawn        string         // the key of the card drawn during the current action, if any
+	extinctions  int            // the number of species that have gone extinct during the current action
+}
+
+// purchase is a card that was bought.
+type purchase struct {
+	cardKey   string
+	dentition int // the buyer
+	genes     int // the card's cost
+	turns     int // the number of turns the card was in the lower display before it was bought
+}
+
+// catastrophe is a catastrophe card that was drawn, and the number of species it made extinct.
+type catastrophe struct {
+	cardKey     string
+	extinctions int
+}
+
+// newGameRecord starts the record of a game.
+func newGameRecord(g *megafauna.Game) *gameRecord {
+	rec := &gameRecord{species: make(map[int]int), tilesPlaced: make(map[string]int), offered: make(map[string]int)}
+	for _, p := range g.Players {
+		rec.dentitions = append(rec.dentitions, p.Dentition)
+	}
+	rec.recordOffers(g)
+	return rec
+}
+
+// Observe counts the tiles placed and the species that go extinct, and remembers the card drawn.
+func (rec *gameRecord) Observe(g *megafauna.Game, e *megafauna.GameEvent) {
+	switch e.Type {
+	case megafauna.EventCardDrawn:
+		rec.drawn = e.CardKey
+	case megafauna.EventTilePlaced:
+		rec.tilesPlaced[e.TileKey]++
+	case megafauna.EventSpeciesExtinct:
+		rec.extinctions++
+	}
+}
+
+// record records what the last action, a, did, given the state of the game before it.
+func (rec *gameRecord) record(g *megafauna.Game, a *megafauna.Action, before *snapshotState) {
+	drawn, extinctions := rec.drawn, rec.extinctions
+	rec.drawn, rec.extinctions = "", 0
+	if a.Type == megafauna.ActionBuyCard {
+		for cost, key := range before.display {
+			if key == a.CardKey {
+				turns := g.Turn - 1 - rec.offered[key]
+				rec.purchases = append(rec.purchases, purchase{cardKey: key, dentition: a.Dentition, genes: cost, turns: turns})
+			}
+		}
+	}
+	rec.recordOffers(g)
+	if drawn != "" && g.Cards[drawn].Event.IsCatastrophe {
+		rec.catastrophes = append(rec.catastrophes, catastrophe{cardKey: drawn, extinctions: extinctions})
+	}
+}
+
+// recordOffers records the turn on which each card in the lower display got there, if it's new.
+func (rec *gameRecord) recordOffers(g *megafauna.Game) {
+	for _, key := range g.LowerDisplayCardKeys {
+		if _, ok := rec.offered[key]; !ok {
+			rec.offered[key] = g.Turn
+		}
+	}
+}
+
+// finish records the end of the game.
+func (rec *gameRecord) finish(g *megafauna.Game) {
+	rec.winner = g.Winner().Dentition
+	for _, p := range g.Players {
+		for silhouette := range p.Genomes {
+			if p.HasSpecies(silhouette) {
+				rec.species[p.Dentition]++
+			}
+		}
+	}
+}
+
+// Report sums up the records of many games.
+type Report struct {
+	Games          int
+	AverageSpecies float64 // per player, at the end of the game
+	Dentitions     []*DentitionStats
+	Sides          []*SideStats
+	Catastrophes   []*CatastropheStats
+	Tiles          []*TileStats
+	Cards          []*CardStats
+}
+
+// DentitionStats are the results of the players with one dentition.
+type DentitionStats struct {
+	Dentition      int
+	Color          string
+	Games          int
+	Wins           int
+	WinRate        float64
+	Species        int // the number of living species at the end of its games
+	AverageSpecies float64
+}
+
+// SideStats are the results of the dinosaurs or of the mammals.
+type SideStats struct {
+	Side    string
+	Games   int // the number of games that the side played in
+	Wins    int
+	WinRate float64
+}
+
+// CatastropheStats are how often a catastrophe card made species extinct.
+type CatastropheStats struct {
+	CardKey        string
+	Drawn          int     // the number of times the card was drawn
+	WithExtinction int     // the number of those times that at least one species went extinct
+	Extinctions    int     // the number of species that went extinct
+	ExtinctionRate float64 // WithExtinction / Drawn
+}
+
+// TileStats are how often a biome tile was placed.
+type TileStats struct {
+	TileKey string
+	Title   string
+	Placed  int
+	PerGame float64
+}
+
+// CardStats are how often a mutation or genotype card was bought, for how much, how soon, and by whom.
+type CardStats struct {
+	CardKey                string
+	Kind                   string  // "Mutation" or "Genotype"
+	Offered                int     // the number of games in which the card came into the lower display
+	Purchases              int     // the number of games in which it was bought
+	PurchaseRate           float64 // Purchases / Offered
+	GenesPaid              int     // the genes paid for the card, over all of its purchases
+	AverageGenesPaid       float64 // GenesPaid / Purchases
+	WinsWhenBought         int     // the number of purchases by the player who went on to win the game
+	WinRateWhenBought      float64 // WinsWhenBought / Purchases
+	TurnsToPurchase        int     // the turns the card was in the lower display before it was bought, over all purchases
+	AverageTurnsToPurchase float64 // TurnsToPurchase / Purchases
+}
+
+// newReport sums up the records of games played with a RuleSet.
+func newReport(rules *megafauna.RuleSet, records []*gameRecord) *Report {
+	r := &Report{Games: len(records)}
+	sides := map[string]*SideStats{
+		megafauna.SideDinosaur: {Side: megafauna.SideDinosaur},
+		megafauna.SideMammal:   {Side: megafauna.SideMammal},
+	}
+	catastrophes := make(map[string]*CatastropheStats)
+	for _, key := range sortedKeys(rules.Cards, func(c *megafauna.Card) bool { return c.Event.IsCatastrophe }) {
+		catastrophes[key] = &CatastropheStats{CardKey: key}
+		r.Catastrophes = append(r.Catastrophes, catastrophes[key])
+	}
+	tiles := make(map[string]*TileStats)
+	for _, key := range sortedKeys(rules.Tiles, func(t *megafauna.Tile) bool { return t.IsBiomeTile() }) {
+		tiles[key] = &TileStats{TileKey: key, Title: rules.Tiles[key].Title}
+		r.Tiles = append(r.Tiles, tiles[key])
+	}
+	cards := make(map[string]*CardStats)
+	for _, key := range sortedKeys(rules.Cards, func(c *megafauna.Card) bool { return c.Mutation != nil || c.Genotype != nil }) {
+		cards[key] = &CardStats{CardKey: key, Kind: "Mutation"}
+		if rules.Cards[key].Genotype != nil {
+			cards[key].Kind = "Genotype"
+		}
+		r.Cards = append(r.Cards, cards[key])
+	}
+	for d := 2; d <= 5; d++ {
+		r.Dentitions = append(r.Dentitions, &DentitionStats{Dentition: d, Color: megafauna.PlayerColors[d-2]})
+	}
+
+	players, species := 0, 0
+	for _, rec := range records {
+		played := make(map[string]bool)
+		for _, d := range rec.dentitions {
+			stats := r.Dentitions[d-2]
+			stats.Games++
+			stats.Species += rec.species[d]
+			players++
+			species += rec.species[d]
+			played[side(d)] = true
+		}
+		for s := range played {
+			sides[s].Games++
+		}
+		r.Dentitions[rec.winner-2].Wins++
+		sides[side(rec.winner)].Wins++
+		for _, c := range rec.catastrophes {
+			stats := catastrophes[c.cardKey]
+			stats.Drawn++
+			stats.Extinctions += c.extinctions
+			if c.extinctions > 0 {
+				stats.WithExtinction++
+			}
+		}
+		for key := range rec.offered {
+			if stats := cards[key]; stats != nil {
+				stats.Offered++
+			}
+		}
+		for _, p := range rec.purchases {
+			if stats := cards[p.cardKey]; stats != nil {
+				stats.Purchases++
+				stats.GenesPaid += p.genes
+				stats.TurnsToPurchase += p.turns
+				if p.dentition == rec.winner {
+					stats.WinsWhenBought++
+				}
+			}
+		}
+		for key, n := range rec.tilesPlaced {
+			if stats := tiles[key]; stats != nil {
+				stats.Placed += n
+			}
+		}
+	}
+
+	r.AverageSpecies = ratio(species, players)
+	for _, stats := range r.Dentitions {
+		stats.WinRate = ratio(stats.Wins, stats.Games)
+		stats.AverageSpecies = ratio(stats.Species, stats.Games)
+	}
+	for _, s := range []string{megafauna.SideDinosaur, megafauna.SideMammal} {
+		sides[s].WinRate = ratio(sides[s].Wins, sides[s].Games)
+		r.Sides = append(r.Sides, sides[s])
+	}
+	for _, stats := range r.Catastrophes {
+		stats.ExtinctionRate = ratio(stats.WithExtinction, stats.Drawn)
+	}
+	for _, stats := range r.Tiles {
+		stats.PerGame = ratio(stats.Placed, r.Games)
+	}
+	for _, stats := range r.Cards {
+		stats.PurchaseRate = ratio(stats.Purchases, stats.Offered)
+		stats.AverageGenesPaid = ratio(stats.GenesPaid, stats.Purchases)
+		stats.WinRateWhenBought = ratio(stats.WinsWhenBought, stats.Purchases)
+		stats.AverageTurnsToPurchase = ratio(stats.TurnsToPurchase, stats.Purchases)
+	}
+	return r
+}
+
+// side returns the side that the player with the given dentition plays.
+func side(dentition int) string {
+	if dentition == 2 || dentition == 4 {
+		return megafauna.SideDinosaur
+	}
+	return megafauna.SideMammal
+}
+
+// ratio returns n / total, or 0 if total is 0.
+func ratio(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// sortedKeys returns the keys of the values in a map that pass a test, in order.
+func sortedKeys[T any](m map[string]T, keep func(T) bool) []string {
+	keys := make([]string, 0)
+	for k, v := range m {
+		if keep(v) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeJSON writes a report as indented JSON.
+func writeJSON(w io.Writer, r *Report) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(r)
+}
+
+// writeCSV writes a report as CSV, one statistic per row, so that a spreadsheet can pivot on the columns:
+//
+//	stat,key,count,total,rate
+//	win,Red,12,50,0.24                   wins by dentition, out of games played
+//	species,Red,63,50,1.26               living species at the end by dentition (or "all"), out of players
+//	side_win,Dinosaur,27,50,0.54         wins by side, out of games played
+//	extinction,M17,3,5,0.6               draws of a catastrophe card that made a species extinct, out of draws
+//	extinct_species,M17,4,5,0.8          species made extinct by a catastrophe card, out of draws
+//	tile_placed,MA16,31,50,0.62          placements of a biome tile, out of games
+//	card_bought,M3,20,40,0.5             games in which a card was bought, out of games it was offered in
+//	card_genes_paid,M3,38,20,1.9         genes paid for a card, out of purchases
+//	card_win_when_bought,M3,7,20,0.35    purchases of a card by the winner, out of purchases
+//	card_turns_to_purchase,M3,90,20,4.5  turns a card waited in the lower display, out of purchases
+func writeCSV(w io.Writer, r *Report) error {
+	rows := [][]string{{"stat", "key", "count", "total", "rate"}}
+	row := func(stat, key string, count, total int, rate float64) {
+		rows = append(rows, []string{stat, key, fmt.Sprint(count), fmt.Sprint(total), fmt.Sprint(rate)})
+	}
+	players, species := 0, 0
+	for _, d := range r.Dentitions {
+		row("win", d.Color, d.Wins, d.Games, d.WinRate)
+		players += d.Games
+		species += d.Species
+	}
+	for _, d := range r.Dentitions {
+		row("species", d.Color, d.Species, d.Games, d.AverageSpecies)
+	}
+	row("species", "all", species, players, r.AverageSpecies)
+	for _, s := range r.Sides {
+		row("side_win", s.Side, s.Wins, s.Games, s.WinRate)
+	}
+	for _, c := range r.Catastrophes {
+		row("extinction", c.CardKey, c.WithExtinction, c.Drawn, c.ExtinctionRate)
+	}
+	for _, c := range r.Catastrophes {
+		row("extinct_species", c.CardKey, c.Extinctions, c.Drawn, ratio(c.Extinctions, c.Drawn))
+	}
+	for _, t := range r.Tiles {
+		row("tile_placed", t.TileKey, t.Placed, r.Games, t.PerGame)
+	}
+	for _, c := range r.Cards {
+		row("card_bought", c.CardKey, c.Purchases, c.Offered, c.PurchaseRate)
+	}
+	for _, c := range r.Cards {
+		row("card_genes_paid", c.CardKey, c.GenesPaid, c.Purchases, c.AverageGenesPaid)
+	}
+	for _, c := range r.Cards {
+		row("card_win_when_bought", c.CardKey, c.WinsWhenBought, c.Purchases, c.WinRateWhenBought)
+	}
+	for _, c := range r.Cards {
+		row("card_turns_to_purchase", c.CardKey, c.TurnsToPurchase, c.Purchases, c.AverageTurnsToPurchase)
+	}
+	cw := csv.NewWriter(w)
+	cw.WriteAll(rows)
+	return cw.Error()
+}
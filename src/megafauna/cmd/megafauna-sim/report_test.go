@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"megafauna"
+	"testing"
+)
+
+// testKeys returns the first catastrophe card, mutation card, genotype card and biome tile in rules, by key.
+func testKeys(t *testing.T, rules *megafauna.RuleSet) (catastrophe, mutation, genotype, tile string) {
+	t.Helper()
+	catastrophe = sortedKeys(rules.Cards, func(c *megafauna.Card) bool { return c.Event.IsCatastrophe })[0]
+	mutation = sortedKeys(rules.Cards, func(c *megafauna.Card) bool { return c.Mutation != nil })[0]
+	genotype = sortedKeys(rules.Cards, func(c *megafauna.Card) bool { return c.Genotype != nil })[0]
+	tile = sortedKeys(rules.Tiles, func(t *megafauna.Tile) bool { return t.IsBiomeTile() })[0]
+	return
+}
+
+func TestNewReport(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	catastropheKey, mutationKey, genotypeKey, tileKey := testKeys(t, rules)
+	records := []*gameRecord{
+		{
+			dentitions:   []int{2, 3},
+			winner:       2,
+			species:      map[int]int{2: 3, 3: 1},
+			catastrophes: []catastrophe{{cardKey: catastropheKey, extinctions: 2}},
+			tilesPlaced:  map[string]int{tileKey: 2},
+			offered:      map[string]int{mutationKey: 0, genotypeKey: 1},
+			purchases:    []purchase{{cardKey: mutationKey, dentition: 2, genes: 2, turns: 3}},
+		},
+		{
+			dentitions:   []int{2, 5},
+			winner:       5,
+			species:      map[int]int{2: 0, 5: 2},
+			catastrophes: []catastrophe{{cardKey: catastropheKey, extinctions: 0}},
+			tilesPlaced:  map[string]int{tileKey: 1},
+			offered:      map[string]int{mutationKey: 0},
+			purchases:    []purchase{{cardKey: mutationKey, dentition: 2, genes: 1, turns: 1}},
+		},
+	}
+	r := newReport(rules, records)
+
+	dentition := func(d int) *DentitionStats { return r.Dentitions[d-2] }
+	card := func(key string) *CardStats {
+		for _, c := range r.Cards {
+			if c.CardKey == key {
+				return c
+			}
+		}
+		t.Fatalf("There are no stats for card %v.", key)
+		return nil
+	}
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"games", r.Games, 2},
+		{"average species", r.AverageSpecies, 1.5},
+		{"Red games", dentition(2).Games, 2},
+		{"Red wins", dentition(2).Wins, 1},
+		{"Red win rate", dentition(2).WinRate, 0.5},
+		{"Red species", dentition(2).Species, 3},
+		{"Red average species", dentition(2).AverageSpecies, 1.5},
+		{"Orange games", dentition(3).Games, 1},
+		{"Orange wins", dentition(3).Wins, 0},
+		{"Green games", dentition(4).Games, 0},
+		{"Green win rate", dentition(4).WinRate, 0.0},
+		{"White wins", dentition(5).Wins, 1},
+		{"White average species", dentition(5).AverageSpecies, 2.0},
+		{"dinosaur games", r.Sides[0].Games, 2},
+		{"dinosaur wins", r.Sides[0].Wins, 1},
+		{"mammal games", r.Sides[1].Games, 2},
+		{"mammal win rate", r.Sides[1].WinRate, 0.5},
+		{"catastrophe drawn", r.Catastrophes[0].Drawn, 2},
+		{"catastrophe with extinction", r.Catastrophes[0].WithExtinction, 1},
+		{"catastrophe extinctions", r.Catastrophes[0].Extinctions, 2},
+		{"catastrophe extinction rate", r.Catastrophes[0].ExtinctionRate, 0.5},
+		{"tile placed", r.Tiles[0].Placed, 3},
+		{"tile per game", r.Tiles[0].PerGame, 1.5},
+		{"mutation offered", card(mutationKey).Offered, 2},
+		{"mutation purchases", card(mutationKey).Purchases, 2},
+		{"mutation genes paid", card(mutationKey).GenesPaid, 3},
+		{"mutation average genes paid", card(mutationKey).AverageGenesPaid, 1.5},
+		{"mutation wins when bought", card(mutationKey).WinsWhenBought, 1},
+		{"mutation win rate when bought", card(mutationKey).WinRateWhenBought, 0.5},
+		{"mutation turns to purchase", card(mutationKey).TurnsToPurchase, 4},
+		{"mutation average turns to purchase", card(mutationKey).AverageTurnsToPurchase, 2.0},
+		{"genotype kind", card(genotypeKey).Kind, "Genotype"},
+		{"genotype offered", card(genotypeKey).Offered, 1},
+		{"genotype purchase rate", card(genotypeKey).PurchaseRate, 0.0},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("Expected %v to be %v, got %v", test.name, test.want, test.got)
+		}
+	}
+
+	// the CSV has the same counts
+	var b bytes.Buffer
+	if err := writeCSV(&b, r); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvTests := []struct {
+		stat, key, count, total, rate string
+	}{
+		{"win", "Red", "1", "2", "0.5"},
+		{"species", "Red", "3", "2", "1.5"},
+		{"species", "all", "6", "4", "1.5"},
+		{"side_win", megafauna.SideMammal, "1", "2", "0.5"},
+		{"extinct_species", catastropheKey, "2", "2", "1"},
+		{"tile_placed", tileKey, "3", "2", "1.5"},
+		{"card_genes_paid", mutationKey, "3", "2", "1.5"},
+		{"card_win_when_bought", mutationKey, "1", "2", "0.5"},
+		{"card_turns_to_purchase", mutationKey, "4", "2", "2"},
+	}
+	for _, test := range csvTests {
+		found := false
+		for _, row := range rows {
+			if row[0] == test.stat && row[1] == test.key {
+				found = true
+				if row[2] != test.count || row[3] != test.total || row[4] != test.rate {
+					t.Errorf("Expected %v,%v to be %v,%v,%v, got %v", test.stat, test.key, test.count, test.total, test.rate, row[2:])
+				}
+			}
+		}
+		if !found {
+			t.Errorf("There's no %v row for %v.", test.stat, test.key)
+		}
+	}
+}
+
+func TestGameRecord_Purchase(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := megafauna.NewGameWithSeed(rules, []megafauna.Seat{{Name: "A", Dentition: 2}, {Name: "B", Dentition: 3}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := newGameRecord(g)
+	g.AddObserver(rec)
+	apply := func(a *megafauna.Action) {
+		t.Helper()
+		before := snapshot(g)
+		if err := g.Apply(a); err != nil {
+			t.Fatal(err)
+		}
+		rec.record(g, a, before)
+	}
+
+	// the card in the second space of the display has been there since the start of the game
+	for i := 0; i < 3; i++ {
+		apply(&megafauna.Action{Type: megafauna.ActionPass, Dentition: g.GetActivePlayer().Dentition, Silhouette: -1})
+	}
+	key := g.LowerDisplayCardKeys[1]
+	var buy *megafauna.Action
+	for _, a := range g.LegalActions(g.GetActivePlayer().Dentition) {
+		if a.Type == megafauna.ActionBuyCard && a.CardKey == key {
+			buy = a
+			break
+		}
+	}
+	if buy == nil {
+		t.Fatalf("Can't buy %v.", key)
+	}
+	apply(buy)
+
+	if len(rec.purchases) != 1 {
+		t.Fatalf("Expected one purchase, got %v", rec.purchases)
+	}
+	expected := purchase{cardKey: key, dentition: buy.Dentition, genes: 1, turns: 3}
+	if rec.purchases[0] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, rec.purchases[0])
+	}
+	if newKey := g.LowerDisplayCardKeys[len(g.LowerDisplayCardKeys)-1]; rec.offered[newKey] != g.Turn {
+		t.Errorf("Expected %v to be offered on turn %v, got %v", newKey, g.Turn, rec.offered[newKey])
+	}
+}
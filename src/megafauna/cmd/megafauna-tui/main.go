@@ -0,0 +1,81 @@
+// Command megafauna-tui lets 2-4 people play megafauna hotseat in a terminal.  Give the players' names as
+// arguments; on each turn, the active player picks an action from the numbered list of legal actions, and
+// then everyone sees what happened as a result.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"megafauna"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	seed := flag.Int64("seed", 0, "seed for shuffling; 0 uses the time")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: megafauna-tui [-seed n] name name [name [name]]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	megafauna.SeedRand(*seed)
+	g, err := megafauna.NewGame(flag.Args())
+	if err == megafauna.ErrInvalidPlayers {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	in := bufio.NewScanner(os.Stdin)
+	out := os.Stdout
+	g.AddObserver(&reporter{out: out})
+	for !g.IsOver {
+		showGame(out, g)
+		p := g.GetActivePlayer()
+		actions := g.LegalActions(p.Dentition)
+		a, ok := chooseAction(out, in, g, p, actions)
+		if !ok {
+			return
+		}
+		fmt.Fprintf(out, "\n%v: %v\n", p.Name, describeAction(g, p, a))
+		if err := g.Apply(a); err != nil {
+			log.Fatal(err)
+		}
+	}
+	showGame(out, g)
+}
+
+// chooseAction asks the player to choose one of their legal actions.  It returns false if they quit, or the
+// input runs out.
+func chooseAction(out io.Writer, in *bufio.Scanner, g *megafauna.Game, p *megafauna.Player, actions []*megafauna.Action) (*megafauna.Action, bool) {
+	fmt.Fprintf(out, "\n%v, it's your turn.\n", p)
+	for i, a := range actions {
+		fmt.Fprintf(out, "%3d) %v\n", i+1, describeAction(g, p, a))
+	}
+	for {
+		fmt.Fprintf(out, "Choose 1-%v (b to show the board, q to quit): ", len(actions))
+		if !in.Scan() {
+			return nil, false
+		}
+		answer := strings.TrimSpace(in.Text())
+		switch answer {
+		case "q":
+			return nil, false
+		case "b":
+			g.Board.Render(out)
+			continue
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(actions) {
+			return actions[n-1], true
+		}
+		fmt.Fprintf(out, "%q isn't one of the choices.\n", answer)
+	}
+}
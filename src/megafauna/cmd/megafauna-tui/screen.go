@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"megafauna"
+	"strings"
+)
+
+// showGame shows the board, the displays, and the players.
+func showGame(out io.Writer, g *megafauna.Game) {
+	v := g.ViewFor(0)
+	fmt.Fprintf(out, "\n%v\n", strings.Repeat("=", 80))
+	if v.IsOver {
+		fmt.Fprintf(out, "Game over after %v turns.  %v wins!\n", v.Turn, g.GetPlayer(v.Winner))
+	} else {
+		fmt.Fprintf(out, "Turn %v, %v era, climate %+d.  Cards left: %v; tiles left: %v Mesozoic, %v Cenozoic.\n",
+			v.Turn+1, v.Era, v.Climate, v.TriassicCards+v.JurassicCards+v.CretaceousCards+v.TertiaryCards,
+			v.MesozoicTiles, v.CenozoicTiles)
+	}
+	g.Board.Render(out)
+
+	fmt.Fprintf(out, "\nUpper display:\n")
+	for _, key := range v.UpperDisplay {
+		fmt.Fprintf(out, "     %v\n", cardName(g, key, nil))
+	}
+	fmt.Fprintf(out, "Lower display:\n")
+	for i, c := range v.LowerDisplay {
+		fmt.Fprintf(out, "     %v (costs %v, %v genes on it)\n", cardName(g, c.Key, nil), i, c.Genes)
+	}
+
+	fmt.Fprintf(out, "\nPlayers:\n")
+	for _, p := range g.Players {
+		fmt.Fprintf(out, "     %v: %v genes, score %v\n", p, p.Genes, p.Score)
+		for s, genome := range p.Genomes {
+			if genome != nil {
+				fmt.Fprintf(out, "         species %v: size %v, DNA %v, %v on the board, %v in supply\n",
+					s, p.SpeciesSizes[s], genome.Spec, len(p.Species[s]), p.AnimalTokens[s])
+			}
+		}
+	}
+}
+
+// cardName returns a card's title.  For a genotype card, it's the title of the half that p would use, or both
+// halves if p is nil.
+func cardName(g *megafauna.Game, key string, p *megafauna.Player) string {
+	c := g.Cards[key]
+	switch {
+	case c == nil:
+		return key
+	case c.Mutation != nil:
+		return fmt.Sprintf("%v [%v, size %v-%v]", c.Mutation.Title, c.Mutation.Mutation.Spec, c.Mutation.MinSize, c.Mutation.MaxSize)
+	case p != nil && p.IsDinosaur:
+		return genotypeName(c.Genotype.DinosaurData)
+	case p != nil:
+		return genotypeName(c.Genotype.MammalData)
+	}
+	return genotypeName(c.Genotype.DinosaurData) + " / " + genotypeName(c.Genotype.MammalData)
+}
+
+func genotypeName(d *megafauna.GenotypeCardData) string {
+	return fmt.Sprintf("%v [%v, species %v, size %v]", d.Title, d.DNASpec.Spec, d.SilhouetteIndex, d.MinSize)
+}
+
+// describeAction describes an action that p can take, or has taken.
+func describeAction(g *megafauna.Game, p *megafauna.Player, a *megafauna.Action) string {
+	switch a.Type {
+	case megafauna.ActionPass:
+		return "Pass, and take a gene"
+	case megafauna.ActionBuyCard:
+		s := "Buy " + cardName(g, a.CardKey, p)
+		if a.Silhouette < 0 {
+			return s + " for its genes"
+		}
+		return fmt.Sprintf("%v for species %v", s, a.Silhouette)
+	case megafauna.ActionPopulate:
+		title := ""
+		if h := g.Board.HabitatMap[a.HabitatKey]; h != nil && h.Biome != nil {
+			title = h.Biome.Tile.Title
+		}
+		return fmt.Sprintf("Put species %v in %v (%v) as a %v", a.Silhouette, a.HabitatKey, title, strings.ToLower(a.Slot))
+	}
+	return string(a.Type)
+}
+
+// reporter is an Observer that describes what happens in the game as it happens: cards drawn and their
+// events, tiles placed, immigrants arriving, animals culled, extinctions, scores and the climate.
+type reporter struct {
+	out io.Writer
+}
+
+func (r *reporter) Observe(g *megafauna.Game, e *megafauna.GameEvent) {
+	switch e.Type {
+	case megafauna.EventCardDrawn:
+		fmt.Fprintf(r.out, "  Drawn: %v\n", cardName(g, e.CardKey, nil))
+		if event := g.Cards[e.CardKey].Event; event != nil && event.Description != "" {
+			fmt.Fprintf(r.out, "    Event: %v\n", event.Description)
+		}
+	case megafauna.EventTileDisplaced:
+		fmt.Fprintf(r.out, "  %v is displaced from %v\n", g.Tiles[e.TileKey].Title, e.HabitatKey)
+	case megafauna.EventTilePlaced:
+		fmt.Fprintf(r.out, "  %v placed in %v\n", g.Tiles[e.TileKey].Title, e.HabitatKey)
+	case megafauna.EventAnimalPlaced:
+		if e.ImmigrantKey != "" {
+			fmt.Fprintf(r.out, "  Immigrant: %v %v arrives in %v\n", describeAnimal(g, e), strings.ToLower(e.Slot), e.HabitatKey)
+		}
+	case megafauna.EventAnimalCulled:
+		fmt.Fprintf(r.out, "  Culled: %v %v in %v\n", describeAnimal(g, e), strings.ToLower(e.Slot), e.HabitatKey)
+	case megafauna.EventSpeciesExtinct:
+		fmt.Fprintf(r.out, "  Extinct: %v's species %v\n", g.GetPlayer(e.Dentition).Name, e.Silhouette)
+	case megafauna.EventScoreChanged:
+		fmt.Fprintf(r.out, "  %v's score is now %v\n", g.GetPlayer(e.Dentition).Name, e.Score)
+	case megafauna.EventClimateChanged:
+		fmt.Fprintf(r.out, "  The climate is now %+d\n", e.Climate)
+	}
+}
+
+// describeAnimal names the animal in an event: its player and species, or the immigrant tile it came from.
+func describeAnimal(g *megafauna.Game, e *megafauna.GameEvent) string {
+	if e.ImmigrantKey != "" {
+		return fmt.Sprintf("immigrant %v", g.Tiles[e.ImmigrantKey].Title)
+	}
+	return fmt.Sprintf("%v's species %v", g.GetPlayer(e.Dentition).Name, e.Silhouette)
+}
@@ -0,0 +1,183 @@
+package megafauna
+
+// Cull resolves the contests in every biome in the given latitudes, or in every biome on the board if no
+// latitudes are given.  In each biome, the herbivore contest and the rooter contest each leave at most one
+// survivor, and then the predators compete for the surviving prey.  Animals that lose go back to their
+// owners' supplies (or, for immigrants, to the tarpit), and any species that loses its last animal on the
+// board goes extinct.
+func (g *Game) Cull(latitudeKeys ...string) {
+	g.cull(g.animalsOnBoard(), latitudeKeys...)
+}
+
+// cull is Cull, but makes extinct the species that had animals on the board when before was recorded, so
+// that animals killed before the cull (e.g. by a catastrophe) count too.
+func (g *Game) cull(before [][]int, latitudeKeys ...string) {
+	for _, b := range g.biomesInLatitudes(latitudeKeys) {
+		survivors := b.cullSurvivors()
+		for _, slot := range [][]*Animal{b.Herbivore, b.Rooter, b.Predator} {
+			for _, a := range copyAnimals(slot) {
+				if !containsAnimal(survivors, a) {
+					g.kill(b, a)
+				}
+			}
+		}
+	}
+
+	g.checkExtinctions(before)
+}
+
+// cullSurvivors returns the animals in b that would survive a cull, without killing any of them: the winners
+// of the herbivore and rooter contests, and the predators that win the prey among them.
+func (b *Biome) cullSurvivors() []*Animal {
+	niche := b.Tile.BiomeData.Niche
+	survivors := make([]*Animal, 0)
+	if len(b.Herbivore) > 0 {
+		contest := &HerbivoreContest{Animals: copyAnimals(b.Herbivore), Requirements: b.Tile.BiomeData.Requirements, Niche: niche}
+		if winner := contest.FindWinner(); winner != nil {
+			survivors = append(survivors, winner)
+		}
+	}
+	if len(b.Rooter) > 0 {
+		requirements := b.Tile.BiomeData.RooterRequirements
+		if requirements == nil {
+			requirements = b.Tile.BiomeData.Requirements
+		}
+		contest := &HerbivoreContest{Animals: copyAnimals(b.Rooter), Requirements: requirements, Niche: niche}
+		if winner := contest.FindWinner(); winner != nil {
+			survivors = append(survivors, winner)
+		}
+	}
+	if len(b.Predator) > 0 {
+		contest := &CarnivoreContest{Carnivores: copyAnimals(b.Predator), Prey: copyAnimals(survivors)}
+		survivors = append(survivors, contest.FindWinners()...)
+	}
+	return survivors
+}
+
+// biomesInLatitudes returns the biomes in the given latitudes, or all of them if no latitudes are given.
+func (g *Game) biomesInLatitudes(latitudeKeys []string) []*Biome {
+	if len(latitudeKeys) == 0 {
+		return g.Board.Biomes()
+	}
+	biomes := make([]*Biome, 0)
+	for _, key := range latitudeKeys {
+		lat := g.Board.LatitudeMap[key]
+		if lat == nil {
+			continue
+		}
+		for _, h := range lat.Habitats {
+			if h.Biome != nil {
+				biomes = append(biomes, h.Biome)
+			}
+		}
+	}
+	return biomes
+}
+
+// kill removes an animal from a biome.  A player's animal token goes back to the player's supply, and an
+// immigrant's tile goes to the tarpit.
+func (g *Game) kill(b *Biome, a *Animal) {
+	switch {
+	case containsAnimal(b.Predator, a):
+		g.notifyAnimal(EventAnimalCulled, b, SlotPredator, a)
+	case containsAnimal(b.Herbivore, a):
+		g.notifyAnimal(EventAnimalCulled, b, SlotHerbivore, a)
+	case containsAnimal(b.Rooter, a):
+		g.notifyAnimal(EventAnimalCulled, b, SlotRooter, a)
+	}
+	b.Predator = removeAnimal(b.Predator, a)
+	b.Herbivore = removeAnimal(b.Herbivore, a)
+	b.Rooter = removeAnimal(b.Rooter, a)
+
+	if a.ImmigrantTile != nil {
+		g.TarpitTileKeys = append(g.TarpitTileKeys, a.ImmigrantTile.Key)
+		return
+	}
+	p := g.GetPlayer(a.Dentition)
+	if p == nil {
+		return
+	}
+	p.Species[a.Silhouette] = removeAnimal(p.Species[a.Silhouette], a)
+	p.AnimalTokens[a.Silhouette]++
+}
+
+// animalsOnBoard returns the number of animals each player has on the board, indexed by player and then
+// by silhouette.
+func (g *Game) animalsOnBoard() [][]int {
+	counts := make([][]int, len(g.Players))
+	for i, p := range g.Players {
+		counts[i] = make([]int, len(p.Species))
+		for s, animals := range p.Species {
+			counts[i][s] = len(animals)
+		}
+	}
+	return counts
+}
+
+// checkExtinctions makes extinct every species that had animals on the board before a cull, as recorded in
+// before, and has none now.
+func (g *Game) checkExtinctions(before [][]int) {
+	for i, p := range g.Players {
+		for s, animals := range p.Species {
+			if before[i][s] > 0 && len(animals) == 0 {
+				p.Genomes[s] = nil
+				p.SpeciesSizes[s] = 0
+				g.notify(&GameEvent{Type: EventSpeciesExtinct, Dentition: p.Dentition, Silhouette: s})
+			}
+		}
+	}
+}
+
+// score gives each player a point for each of their animals on the board, and another for each of those
+// animals that's in a biome with a red or blue star.
+func (g *Game) score() {
+	before := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		before[i] = p.Score
+	}
+	for _, b := range g.Board.Biomes() {
+		data := b.Tile.BiomeData
+		for _, a := range b.Animals() {
+			p := g.GetPlayer(a.Dentition)
+			if p == nil {
+				continue
+			}
+			p.Score++
+			if data.RedStar || data.BlueStar {
+				p.Score++
+			}
+		}
+	}
+	for i, p := range g.Players {
+		if p.Score != before[i] {
+			g.notify(&GameEvent{Type: EventScoreChanged, Dentition: p.Dentition, Score: p.Score})
+		}
+	}
+}
+
+// copyAnimals returns a copy of a slice of animals, so that the original can be changed while iterating.
+func copyAnimals(animals []*Animal) []*Animal {
+	result := make([]*Animal, len(animals))
+	copy(result, animals)
+	return result
+}
+
+// removeAnimal removes an animal from a slice of animals, if it's there.
+func removeAnimal(animals []*Animal, a *Animal) []*Animal {
+	for i, other := range animals {
+		if other == a {
+			return append(animals[:i:i], animals[i+1:]...)
+		}
+	}
+	return animals
+}
+
+// containsAnimal tells you whether an animal is in a slice of animals.
+func containsAnimal(animals []*Animal, a *Animal) bool {
+	for _, other := range animals {
+		if other == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package megafauna
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// EvalWeights are the weights of the terms that Evaluate adds up.  They can be loaded from a JSON or YAML
+// config, so that bots and hints share the same tuning; any weight the config leaves out keeps its default.
+type EvalWeights struct {
+	Score     float64 `json:"score"`     // per point the player has already scored
+	Biomes    float64 `json:"biomes"`    // per biome the player has an animal in
+	Survivors float64 `json:"survivors"` // per point the player's animals would score if the board were culled now
+	Fitness   float64 `json:"fitness"`   // per species, times the fraction of the biomes on the board it can feed in
+	Genes     float64 `json:"genes"`     // per gene in the player's hand
+}
+
+// DefaultEvalWeights are the weights used by Evaluate.  Points already scored can't be lost, and points from
+// survivors are as good as scored at the next cull, so they count the most.
+var DefaultEvalWeights = EvalWeights{
+	Score:     1,
+	Biomes:    0.25,
+	Survivors: 0.75,
+	Fitness:   0.5,
+	Genes:     0.2,
+}
+
+// ParseEvalWeightsJSON parses EvalWeights in JSON format.  Weights that aren't given keep their defaults.
+func ParseEvalWeightsJSON(r io.Reader) (*EvalWeights, error) {
+	w := DefaultEvalWeights
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&w)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// ParseEvalWeightsYAML parses EvalWeights in YAML format.  Weights that aren't given keep their defaults.
+func ParseEvalWeightsYAML(r io.Reader) (*EvalWeights, error) {
+	w := DefaultEvalWeights
+	err := decodeYAML(r, &w)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Evaluate scores a position from the point of view of the player with the given dentition, using
+// DefaultEvalWeights.  Higher is better.  It returns 0 if there's no such player.
+func Evaluate(g *Game, dentition int) float64 {
+	return DefaultEvalWeights.Evaluate(g, dentition)
+}
+
+// Evaluate scores a position from the point of view of the player with the given dentition: the points they
+// have already scored, the biomes they hold, the points that their animals would score if the board were
+// culled now (as the herbivore and carnivore contests would come out), how well their species' genomes fit
+// the requirements of the biomes on the board, and the genes in their hand.  It doesn't change the game.
+func (w *EvalWeights) Evaluate(g *Game, dentition int) float64 {
+	p := g.GetPlayer(dentition)
+	if p == nil {
+		return 0
+	}
+
+	biomes, survivors := 0, 0
+	for _, b := range g.Board.Biomes() {
+		for _, a := range b.Animals() {
+			if a.Dentition == dentition && a.ImmigrantTile == nil {
+				biomes++
+				break
+			}
+		}
+		data := b.Tile.BiomeData
+		counted := make([]*Animal, 0)
+		for _, a := range b.cullSurvivors() {
+			if a.Dentition != dentition || a.ImmigrantTile != nil || containsAnimal(counted, a) {
+				continue
+			}
+			counted = append(counted, a)
+			survivors++
+			if data.RedStar || data.BlueStar {
+				survivors++
+			}
+		}
+	}
+
+	fitness := 0.0
+	for s, genome := range p.Genomes {
+		if genome != nil {
+			fitness += g.speciesFitness(p, s)
+		}
+	}
+
+	return w.Score*float64(p.Score) + w.Biomes*float64(biomes) + w.Survivors*float64(survivors) +
+		w.Fitness*fitness + w.Genes*float64(p.Genes)
+}
+
+// speciesFitness returns the fraction of the biomes on the board that p's species s could be put in as a
+// herbivore or a rooter, whether or not it's there already.
+func (g *Game) speciesFitness(p *Player, s int) float64 {
+	biomes := g.Board.Biomes()
+	if len(biomes) == 0 {
+		return 0
+	}
+	genome := p.Genomes[s]
+	fit := 0
+	for _, b := range biomes {
+		if b.IsSeaOnly() && genome.GetDNAValue("M") == 0 {
+			continue
+		}
+		data := b.Tile.BiomeData
+		if genome.CanFeedOn(data.Requirements) || (data.RooterRequirements != nil && genome.CanFeedOn(data.RooterRequirements)) {
+			fit++
+		}
+	}
+	return float64(fit) / float64(len(biomes))
+}
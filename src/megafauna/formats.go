@@ -0,0 +1,646 @@
+package megafauna
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The JSON (and YAML) data formats describe the same Cards, Tiles, InheritanceTiles and Latitudes as the CSV
+// data, but with named fields instead of column positions, so that they can be edited by hand.  DNA specs and
+// niches are written the same way as in the CSV data, e.g. "BBG" or "size".  Each file contains a list of
+// entries; the doc types below define the schema of an entry.
+
+// cardDoc is the schema of a Card.  Exactly one of Mutation and Genotype is present.
+type cardDoc struct {
+	Key      string       `json:"key"`
+	Mutation *mutationDoc `json:"mutation,omitempty"`
+	Genotype *genotypeDoc `json:"genotype,omitempty"`
+	Event    *eventDoc    `json:"event"`
+}
+
+// mutationDoc is the schema of a MutationCard.
+type mutationDoc struct {
+	MinSize    int    `json:"minSize"`
+	MaxSize    int    `json:"maxSize"`
+	Mutation   string `json:"mutation"`
+	Instinct   string `json:"instinct,omitempty"`
+	Supertitle string `json:"supertitle,omitempty"`
+	Title      string `json:"title"`
+	Subtitle   string `json:"subtitle,omitempty"`
+	Reminder   string `json:"reminder,omitempty"`
+}
+
+// genotypeDoc is the schema of a GenotypeCard.
+type genotypeDoc struct {
+	Mammal   *genotypeDataDoc `json:"mammal"`
+	Dinosaur *genotypeDataDoc `json:"dinosaur"`
+}
+
+// genotypeDataDoc is the schema of GenotypeCardData.  Silhouette is one of the nicknames in
+// MammalSilhouettes or DinosaurSilhouettes.
+type genotypeDataDoc struct {
+	Silhouette string `json:"silhouette"`
+	Family     string `json:"family"`
+	Title      string `json:"title"`
+	Subtitle   string `json:"subtitle,omitempty"`
+	MinSize    int    `json:"minSize"`
+	MaxSize    int    `json:"maxSize"`
+	DNA        string `json:"dna"`
+}
+
+// Event types used in eventDoc.
+const (
+	eventTypeDrawTwo     = "drawTwo"
+	eventTypeWarming     = "warming"
+	eventTypeCooling     = "cooling"
+	eventTypeCatastrophe = "catastrophe"
+	eventTypeMilankovich = "milankovich"
+)
+
+// eventDoc is the schema of an Event.
+type eventDoc struct {
+	Type                 string   `json:"type"`
+	Description          string   `json:"description,omitempty"`
+	CatastropheLevel     int      `json:"catastropheLevel,omitempty"`
+	IsWarming            bool     `json:"warming,omitempty"` // for catastrophes; if false, the catastrophe causes cooling
+	MilankovichLatitudes []string `json:"milankovichLatitudes,omitempty"`
+}
+
+// tileDoc is the schema of a Tile.  Exactly one of Biome and Immigrant is present.
+type tileDoc struct {
+	Key        string        `json:"key"`
+	IsMesozoic bool          `json:"mesozoic"`
+	Latitude   string        `json:"latitude"`
+	Supertitle string        `json:"supertitle,omitempty"`
+	Title      string        `json:"title"`
+	IsLand     bool          `json:"land,omitempty"`
+	IsSea      bool          `json:"sea,omitempty"`
+	Biome      *biomeDoc     `json:"biome,omitempty"`
+	Immigrant  *immigrantDoc `json:"immigrant,omitempty"`
+}
+
+// biomeDoc is the schema of BiomeTileData.
+type biomeDoc struct {
+	ClimaxNumber       int    `json:"climaxNumber"`
+	Requirements       string `json:"requirements"`
+	RooterRequirements string `json:"rooterRequirements,omitempty"`
+	Niche              string `json:"niche"`
+	RedStar            bool   `json:"redStar,omitempty"`
+	BlueStar           bool   `json:"blueStar,omitempty"`
+	IsWarming          bool   `json:"warming,omitempty"`
+	IsCooling          bool   `json:"cooling,omitempty"`
+}
+
+// immigrantDoc is the schema of ImmigrantTileData.  Size is omitted for predators.
+type immigrantDoc struct {
+	Size int    `json:"size,omitempty"`
+	DNA  string `json:"dna"`
+}
+
+// inheritanceTileDoc is the schema of an InheritanceTile.
+type inheritanceTileDoc struct {
+	Obverse *inheritanceSideDoc `json:"obverse"`
+	Reverse *inheritanceSideDoc `json:"reverse"`
+}
+
+// inheritanceSideDoc is the schema of InheritanceTileData.
+type inheritanceSideDoc struct {
+	MinSize int    `json:"minSize"`
+	MaxSize int    `json:"maxSize"`
+	DNA     string `json:"dna"`
+}
+
+// latitudeDoc is the schema of a Latitude.
+type latitudeDoc struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// ParseCardsJSON parses a list of cards in JSON format into a map of Cards.
+func ParseCardsJSON(r io.Reader) (map[string]*Card, error) {
+	var docs []*cardDoc
+	err := json.NewDecoder(r).Decode(&docs)
+	if err != nil {
+		return nil, err
+	}
+	return cardsFromDocs(docs)
+}
+
+// ParseTilesJSON parses a list of biome and immigrant tiles in JSON format into a map of Tiles.
+func ParseTilesJSON(r io.Reader) (map[string]*Tile, error) {
+	var docs []*tileDoc
+	err := json.NewDecoder(r).Decode(&docs)
+	if err != nil {
+		return nil, err
+	}
+	return tilesFromDocs(docs)
+}
+
+// ParseInheritanceTilesJSON parses a list of inheritance tiles in JSON format.
+func ParseInheritanceTilesJSON(r io.Reader) ([]*InheritanceTile, error) {
+	var docs []*inheritanceTileDoc
+	err := json.NewDecoder(r).Decode(&docs)
+	if err != nil {
+		return nil, err
+	}
+	return inheritanceTilesFromDocs(docs)
+}
+
+// ParseLatitudesJSON parses a list of latitudes in JSON format into a LatitudeMap.
+func ParseLatitudesJSON(r io.Reader) (LatitudeMap, error) {
+	var docs []*latitudeDoc
+	err := json.NewDecoder(r).Decode(&docs)
+	if err != nil {
+		return nil, err
+	}
+	return latitudesFromDocs(docs)
+}
+
+// WriteCardsJSON writes the cards to w in JSON format, in key order.
+func WriteCardsJSON(w io.Writer, cards map[string]*Card) error {
+	return writeJSON(w, cardsToDocs(cards))
+}
+
+// WriteTilesJSON writes the biome and immigrant tiles to w in JSON format, in key order.  Homeland tiles
+// aren't data, so they're skipped.
+func WriteTilesJSON(w io.Writer, tiles map[string]*Tile) error {
+	return writeJSON(w, tilesToDocs(tiles))
+}
+
+// WriteInheritanceTilesJSON writes the inheritance tiles to w in JSON format.
+func WriteInheritanceTilesJSON(w io.Writer, tiles []*InheritanceTile) error {
+	return writeJSON(w, inheritanceTilesToDocs(tiles))
+}
+
+// WriteLatitudesJSON writes the latitudes to w in JSON format, in key order.
+func WriteLatitudesJSON(w io.Writer, latitudes LatitudeMap) error {
+	return writeJSON(w, latitudesToDocs(latitudes))
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// docError makes a DataError for a problem with the field of the index'th entry in a JSON or YAML list.
+func docError(index int, field string, err error) *DataError {
+	return &DataError{Line: index + 1, Field: field, Err: err}
+}
+
+// cardsFromDocs converts card docs to Cards, applying the same checks as the CSV parsers.
+func cardsFromDocs(docs []*cardDoc) (map[string]*Card, error) {
+	cards := make(map[string]*Card)
+	for index, doc := range docs {
+		c, err := cardFromDoc(doc)
+		if err == nil {
+			err = addCard(cards, c)
+		}
+		if err != nil {
+			e := err.(*DataError)
+			e.Line = index + 1
+			return nil, e
+		}
+	}
+	return cards, nil
+}
+
+// cardFromDoc converts a single card doc to a Card.
+func cardFromDoc(doc *cardDoc) (*Card, error) {
+	var err error
+	if doc == nil || doc.Key == "" {
+		return nil, fieldError("key", ErrMissingKey)
+	}
+	c := new(Card)
+	c.Key = doc.Key
+
+	switch {
+	case doc.Mutation != nil && doc.Genotype != nil:
+		return nil, fieldError("mutation", fmt.Errorf("A card can't be both a mutation card and a genotype card."))
+	case doc.Mutation != nil:
+		m := new(MutationCard)
+		d := doc.Mutation
+		m.MinSize = d.MinSize
+		m.MaxSize = d.MaxSize
+		err = checkSizes(m.MinSize, m.MaxSize)
+		if err != nil {
+			return nil, err
+		}
+		m.Mutation = MakeDNASpec(d.Mutation)
+		if m.Mutation == nil {
+			return nil, fieldError("mutation", ErrInvalidDNASpec)
+		}
+		if d.Instinct != "" && (len(d.Instinct) != 1 || !strings.Contains(InstinctKeys, d.Instinct)) {
+			return nil, fieldError("instinct", ErrInvalidInstinctKey)
+		}
+		m.InstinctKey = d.Instinct
+		m.Supertitle = d.Supertitle
+		m.Title = d.Title
+		m.Subtitle = d.Subtitle
+		m.Reminder = d.Reminder
+		c.Mutation = m
+	case doc.Genotype != nil:
+		g := new(GenotypeCard)
+		g.MammalData, err = genotypeDataFromDoc(doc.Genotype.Mammal, "mammal", false)
+		if err != nil {
+			return nil, err
+		}
+		g.DinosaurData, err = genotypeDataFromDoc(doc.Genotype.Dinosaur, "dinosaur", true)
+		if err != nil {
+			return nil, err
+		}
+		c.Genotype = g
+	default:
+		return nil, fieldError("mutation", fmt.Errorf("A card must be either a mutation card or a genotype card."))
+	}
+
+	c.Event, err = eventFromDoc(doc.Event)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// genotypeDataFromDoc converts one half of a genotype card doc to GenotypeCardData.
+func genotypeDataFromDoc(doc *genotypeDataDoc, field string, isDinosaur bool) (*GenotypeCardData, error) {
+	var err error
+	if doc == nil {
+		return nil, fieldError(field, fmt.Errorf("Missing %v data.", field))
+	}
+	g := new(GenotypeCardData)
+	g.SilhouetteIndex, err = convertSilhouette(doc.Silhouette, isDinosaur)
+	if err != nil {
+		return nil, fieldError(field+".silhouette", err)
+	}
+	g.Family = doc.Family
+	g.Title = doc.Title
+	g.Subtitle = doc.Subtitle
+	g.MinSize = doc.MinSize
+	g.MaxSize = doc.MaxSize
+	err = checkSizes(g.MinSize, g.MaxSize)
+	if err != nil {
+		e := err.(*DataError)
+		e.Field = field + "." + e.Field
+		return nil, e
+	}
+	g.DNASpec = MakeDNASpec(doc.DNA)
+	if g.DNASpec == nil {
+		return nil, fieldError(field+".dna", ErrInvalidDNASpec)
+	}
+	return g, nil
+}
+
+// eventFromDoc converts an event doc to an Event, by way of makeEvent.
+func eventFromDoc(doc *eventDoc) (*Event, error) {
+	if doc == nil {
+		return nil, fieldError("event", fmt.Errorf("Missing event."))
+	}
+	var eventType string
+	switch doc.Type {
+	case eventTypeDrawTwo:
+		eventType = "T"
+	case eventTypeWarming:
+		eventType = "GW"
+	case eventTypeCooling:
+		eventType = "GC"
+	case eventTypeCatastrophe:
+		eventType = "C"
+	case eventTypeMilankovich:
+		eventType = "M"
+	default:
+		return nil, fieldError("event.type", fmt.Errorf("\"%v\" is an invalid event type.", doc.Type))
+	}
+	e, err := makeEvent(eventType, strings.Join(doc.MilankovichLatitudes, ""), doc.CatastropheLevel, doc.IsWarming)
+	if err != nil {
+		field := "event.type"
+		if err == ErrInvalidLatitudeKey {
+			field = "event.milankovichLatitudes"
+		}
+		return nil, fieldError(field, err)
+	}
+	if e.IsCatastrophe && e.CatastropheLevel < 1 {
+		return nil, fieldError("event.catastropheLevel", ErrInvalidCatastropheLevel)
+	}
+	e.Description = doc.Description
+	return e, nil
+}
+
+// tilesFromDocs converts tile docs to Tiles, applying the same checks as the CSV parsers.
+func tilesFromDocs(docs []*tileDoc) (map[string]*Tile, error) {
+	tiles := make(map[string]*Tile)
+	for index, doc := range docs {
+		t, err := tileFromDoc(doc)
+		if err == nil {
+			err = addTile(tiles, t)
+		}
+		if err != nil {
+			e := err.(*DataError)
+			e.Line = index + 1
+			return nil, e
+		}
+	}
+	return tiles, nil
+}
+
+// tileFromDoc converts a single tile doc to a Tile.
+func tileFromDoc(doc *tileDoc) (*Tile, error) {
+	if doc == nil || doc.Key == "" {
+		return nil, fieldError("key", ErrMissingKey)
+	}
+	t := new(Tile)
+	t.Key = doc.Key
+	t.IsMesozoic = doc.IsMesozoic
+	t.LatitudeKey = doc.Latitude
+	if len(t.LatitudeKey) != 1 || !strings.Contains(LatitudeKeys, t.LatitudeKey) {
+		return nil, fieldError("latitude", errInvalidLatitudeKey)
+	}
+	t.Supertitle = doc.Supertitle
+	t.Title = doc.Title
+	t.IsLand = doc.IsLand
+	t.IsSea = doc.IsSea
+	if !t.IsLand && !t.IsSea {
+		return nil, fieldError("land", fmt.Errorf("A tile must be land, sea, or both."))
+	}
+
+	switch {
+	case doc.Biome != nil && doc.Immigrant != nil:
+		return nil, fieldError("biome", fmt.Errorf("A tile can't be both a biome and an immigrant."))
+	case doc.Biome != nil:
+		var err error
+		d := doc.Biome
+		b := new(BiomeTileData)
+		b.IsOrogeny = t.IsLand && !t.IsSea && t.LatitudeKey == "O"
+		b.ClimaxNumber = d.ClimaxNumber
+		b.Requirements = MakeDNASpec(d.Requirements)
+		if b.Requirements == nil {
+			return nil, fieldError("biome.requirements", ErrInvalidDNASpec)
+		}
+		if d.RooterRequirements != "" {
+			b.RooterRequirements = MakeDNASpec(d.RooterRequirements)
+			if b.RooterRequirements == nil {
+				return nil, fieldError("biome.rooterRequirements", ErrInvalidDNASpec)
+			}
+		}
+		b.Niche, err = MakeNiche(d.Niche)
+		if err != nil {
+			return nil, fieldError("biome.niche", err)
+		}
+		b.RedStar = d.RedStar
+		b.BlueStar = d.BlueStar
+		b.IsWarming = d.IsWarming
+		b.IsCooling = d.IsCooling
+		t.BiomeData = b
+	case doc.Immigrant != nil:
+		d := doc.Immigrant
+		i := new(ImmigrantTileData)
+		if d.Size < 0 {
+			return nil, fieldError("immigrant.size", ErrInvalidMinSize)
+		}
+		i.Size = d.Size
+		i.IsHerbivore = d.Size > 0
+		i.DNA = MakeDNASpec(d.DNA)
+		if i.DNA == nil {
+			return nil, fieldError("immigrant.dna", ErrInvalidDNASpec)
+		}
+		t.ImmigrantData = i
+	default:
+		return nil, fieldError("biome", fmt.Errorf("A tile must be either a biome or an immigrant."))
+	}
+	return t, nil
+}
+
+// inheritanceTilesFromDocs converts inheritance tile docs to InheritanceTiles.
+func inheritanceTilesFromDocs(docs []*inheritanceTileDoc) ([]*InheritanceTile, error) {
+	var err error
+	result := make([]*InheritanceTile, 0)
+	for index, doc := range docs {
+		if doc == nil {
+			return nil, docError(index, "obverse", fmt.Errorf("Missing inheritance tile."))
+		}
+		t := new(InheritanceTile)
+		t.Obverse, err = inheritanceSideFromDoc(doc.Obverse, "obverse")
+		if err == nil {
+			t.Reverse, err = inheritanceSideFromDoc(doc.Reverse, "reverse")
+		}
+		if err != nil {
+			e := err.(*DataError)
+			e.Line = index + 1
+			return nil, e
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// inheritanceSideFromDoc converts one side of an inheritance tile doc to InheritanceTileData.
+func inheritanceSideFromDoc(doc *inheritanceSideDoc, field string) (*InheritanceTileData, error) {
+	if doc == nil {
+		return nil, fieldError(field, fmt.Errorf("Missing %v.", field))
+	}
+	d := new(InheritanceTileData)
+	d.MinSize = doc.MinSize
+	d.MaxSize = doc.MaxSize
+	err := checkSizes(d.MinSize, d.MaxSize)
+	if err != nil {
+		e := err.(*DataError)
+		e.Field = field + "." + e.Field
+		return nil, e
+	}
+	d.DNA = MakeDNASpec(doc.DNA)
+	if d.DNA == nil {
+		return nil, fieldError(field+".dna", ErrInvalidDNASpec)
+	}
+	return d, nil
+}
+
+// latitudesFromDocs converts latitude docs to a LatitudeMap.
+func latitudesFromDocs(docs []*latitudeDoc) (LatitudeMap, error) {
+	latitudes := make(LatitudeMap)
+	for index, doc := range docs {
+		if doc == nil || doc.Key == "" {
+			return nil, docError(index, "key", ErrMissingKey)
+		}
+		if latitudes[doc.Key] != nil {
+			return nil, docError(index, "key", fmt.Errorf("%w: %v", ErrDuplicateKey, doc.Key))
+		}
+		l := new(Latitude)
+		l.Key = doc.Key
+		l.Name = doc.Name
+		latitudes[l.Key] = l
+	}
+	return latitudes, nil
+}
+
+// cardsToDocs converts Cards to card docs, in key order.
+func cardsToDocs(cards map[string]*Card) []*cardDoc {
+	keys := make([]string, 0, len(cards))
+	for k := range cards {
+		keys = append(keys, k)
+	}
+	sortDataKeys(keys)
+
+	docs := make([]*cardDoc, 0, len(keys))
+	for _, k := range keys {
+		c := cards[k]
+		doc := new(cardDoc)
+		doc.Key = c.Key
+		if m := c.Mutation; m != nil {
+			doc.Mutation = &mutationDoc{
+				MinSize:    m.MinSize,
+				MaxSize:    m.MaxSize,
+				Mutation:   m.Mutation.Spec,
+				Instinct:   m.InstinctKey,
+				Supertitle: m.Supertitle,
+				Title:      m.Title,
+				Subtitle:   m.Subtitle,
+				Reminder:   m.Reminder,
+			}
+		}
+		if g := c.Genotype; g != nil {
+			doc.Genotype = &genotypeDoc{
+				Mammal:   genotypeDataToDoc(g.MammalData, MammalSilhouettes),
+				Dinosaur: genotypeDataToDoc(g.DinosaurData, DinosaurSilhouettes),
+			}
+		}
+		doc.Event = eventToDoc(c.Event)
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// genotypeDataToDoc converts one half of a genotype card to a doc.
+func genotypeDataToDoc(g *GenotypeCardData, silhouettes []string) *genotypeDataDoc {
+	return &genotypeDataDoc{
+		Silhouette: silhouettes[g.SilhouetteIndex],
+		Family:     g.Family,
+		Title:      g.Title,
+		Subtitle:   g.Subtitle,
+		MinSize:    g.MinSize,
+		MaxSize:    g.MaxSize,
+		DNA:        g.DNASpec.Spec,
+	}
+}
+
+// eventToDoc converts an Event to an event doc.
+func eventToDoc(e *Event) *eventDoc {
+	doc := new(eventDoc)
+	doc.Description = e.Description
+	switch {
+	case e.IsDrawTwo:
+		doc.Type = eventTypeDrawTwo
+	case e.IsCatastrophe:
+		doc.Type = eventTypeCatastrophe
+		doc.CatastropheLevel = e.CatastropheLevel
+		doc.IsWarming = e.IsWarming
+	case e.IsMilankovich:
+		doc.Type = eventTypeMilankovich
+		doc.MilankovichLatitudes = e.MilankovichLatitudeKeys
+	case e.IsWarming:
+		doc.Type = eventTypeWarming
+	case e.IsCooling:
+		doc.Type = eventTypeCooling
+	}
+	return doc
+}
+
+// tilesToDocs converts biome and immigrant Tiles to tile docs, in key order.
+func tilesToDocs(tiles map[string]*Tile) []*tileDoc {
+	keys := make([]string, 0, len(tiles))
+	for k, t := range tiles {
+		if t.HomelandPlayer == nil {
+			keys = append(keys, k)
+		}
+	}
+	sortDataKeys(keys)
+
+	docs := make([]*tileDoc, 0, len(keys))
+	for _, k := range keys {
+		t := tiles[k]
+		doc := &tileDoc{
+			Key:        t.Key,
+			IsMesozoic: t.IsMesozoic,
+			Latitude:   t.LatitudeKey,
+			Supertitle: t.Supertitle,
+			Title:      t.Title,
+			IsLand:     t.IsLand,
+			IsSea:      t.IsSea,
+		}
+		if b := t.BiomeData; b != nil {
+			doc.Biome = &biomeDoc{
+				ClimaxNumber: b.ClimaxNumber,
+				Requirements: b.Requirements.Spec,
+				Niche:        b.Niche.String(),
+				RedStar:      b.RedStar,
+				BlueStar:     b.BlueStar,
+				IsWarming:    b.IsWarming,
+				IsCooling:    b.IsCooling,
+			}
+			if b.RooterRequirements != nil {
+				doc.Biome.RooterRequirements = b.RooterRequirements.Spec
+			}
+		}
+		if i := t.ImmigrantData; i != nil {
+			doc.Immigrant = &immigrantDoc{Size: i.Size, DNA: i.DNA.Spec}
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// inheritanceTilesToDocs converts InheritanceTiles to inheritance tile docs.
+func inheritanceTilesToDocs(tiles []*InheritanceTile) []*inheritanceTileDoc {
+	side := func(d *InheritanceTileData) *inheritanceSideDoc {
+		return &inheritanceSideDoc{MinSize: d.MinSize, MaxSize: d.MaxSize, DNA: d.DNA.Spec}
+	}
+	docs := make([]*inheritanceTileDoc, len(tiles))
+	for i, t := range tiles {
+		docs[i] = &inheritanceTileDoc{Obverse: side(t.Obverse), Reverse: side(t.Reverse)}
+	}
+	return docs
+}
+
+// latitudesToDocs converts a LatitudeMap to latitude docs, in the order of LatitudeKeys.
+func latitudesToDocs(latitudes LatitudeMap) []*latitudeDoc {
+	keys := make([]string, 0, len(latitudes))
+	for k := range latitudes {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := strings.Index(LatitudeKeys, keys[i]), strings.Index(LatitudeKeys, keys[j])
+		if a != b {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	docs := make([]*latitudeDoc, len(keys))
+	for i, k := range keys {
+		docs[i] = &latitudeDoc{Key: k, Name: latitudes[k].Name}
+	}
+	return docs
+}
+
+// sortDataKeys sorts card and tile keys the way they appear in the data: by their letters, and then
+// numerically, so that M2 comes before M10.
+func sortDataKeys(keys []string) {
+	split := func(key string) (string, int) {
+		i := len(key)
+		for i > 0 && key[i-1] >= '0' && key[i-1] <= '9' {
+			i--
+		}
+		n, _ := strconv.Atoi(key[i:])
+		return key[:i], n
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, m := split(keys[i])
+		b, n := split(keys[j])
+		if a != b {
+			return a < b
+		}
+		return m < n
+	})
+}
@@ -3,6 +3,7 @@ package megafauna
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
 	"strconv"
 )
@@ -32,6 +33,7 @@ func (p SortablePlayerCollection) Swap(i, j int) {
 
 // Game is one discrete game of Bios Megafauna.
 type Game struct {
+	Rules   *RuleSet                 // the rule data the game was set up from
 	Players SortablePlayerCollection // slice of Player objects, in player order
 	Board   *Board                   // the game's board
 	//
@@ -53,45 +55,137 @@ type Game struct {
 	MesozoicTileKeys []string // shuffled slice of keys to the Mesozoic tiles.
 	CenozoicTileKeys []string // shuffled slice of keys to the Cenozoic tiles.
 	TarpitTileKeys   []string // keys of the Tiles in the Tarpit.
+	//
+	// turn-related fields
+	//
+	ActivePlayer int  // index into Players of the player whose turn it is
+	Turn         int  // the number of actions taken so far
+	Climate      int  // net global warming (positive) or cooling (negative) so far
+	IsOver       bool // true once the last card has been drawn and the final cull scored
+	//
+	// the game's history
+	//
+	Setup   *Setup    // how the game was set up
+	Actions []*Action // every action applied to the game, in order
+
+	observers []Observer // told about everything that happens in the game
 }
 
-// NewGame creates a new Game and initializes the Players.
+// NewGame creates a new Game with the standard rules and initializes the Players.
 func NewGame(names []string) (*Game, error) {
+	rules, err := Init(nil)
+	if err != nil {
+		return nil, err
+	}
+	return NewGameWithRules(rules, names)
+}
+
+// NewGameWithRules creates a new Game from a RuleSet and initializes the Players, who are randomly assigned
+// dentitions.
+func NewGameWithRules(rules *RuleSet, names []string) (*Game, error) {
+	if len(names) < 2 || len(names) > 4 {
+		return nil, ErrInvalidPlayers
+	}
+
+	// Randomly order the dentitions, so that we can randomly assign one to each player.
+	// Shuffle takes strings, so we'll have to convert the dentitions to ints.
+	dentitions := []string{"2", "3", "4", "5"}
+	Shuffle(dentitions)
+
+	seats := make([]Seat, len(names))
+	for index, name := range names {
+		seats[index].Name = name
+		seats[index].Dentition, _ = strconv.Atoi(dentitions[index])
+	}
+	return NewGameWithSeats(rules, seats)
+}
+
+// PlayerColors are the colors of the players, in order of dentition from 2 to 5.
+var PlayerColors = []string{"Red", "Orange", "Green", "White"}
+
+// isDinosaurDentition tells you whether the player with the given dentition plays dinosaurs (rather than
+// mammals).
+func isDinosaurDentition(dentition int) bool {
+	return dentition == 2 || dentition == 4
+}
+
+// Seat is a player's name and the dentition (and so the color and side) they'll play.
+type Seat struct {
+	Name      string
+	Dentition int
+}
+
+// NewGameWithSeats creates a new Game from a RuleSet, with players seated at the given dentitions.  There
+// must be 2-4 seats, each with a different dentition from 2 to 5.
+func NewGameWithSeats(rules *RuleSet, seats []Seat) (*Game, error) {
+	return newGame(rules, seats, Shuffle)
+}
+
+// NewGameWithSeed is NewGameWithSeats, except that the cards and tiles are shuffled by a random number
+// generator with the given seed, so that the same seed always sets up the same game.
+func NewGameWithSeed(rules *RuleSet, seats []Seat, seed int64) (*Game, error) {
+	r := rand.New(rand.NewSource(seed))
+	return newGame(rules, seats, func(keys []string) {
+		shuffleKeys(r, keys)
+	})
+}
+
+// newGame creates a new Game from a RuleSet and seats, using shuffle to shuffle the cards and tiles.
+func newGame(rules *RuleSet, seats []Seat, shuffle func(keys []string)) (*Game, error) {
 	var err error
 
 	g := new(Game)
-	g.Board = NewBoard()
-	err = g.createCards()
+	g.Rules = rules
+	g.Board, err = rules.Board.NewBoard()
 	if err != nil {
 		return nil, err
 	}
-	err = g.createTiles()
+	for key, lat := range g.Board.LatitudeMap {
+		if rules.Latitudes[key] != nil {
+			lat.Name = rules.Latitudes[key].Name
+		}
+	}
+	// the tiles come first, because createPlayers adds the homeland tiles to them, and the players come before
+	// the cards, because the size of the Triassic stack depends on the number of players.
+	err = g.createTiles(shuffle)
 	if err != nil {
 		return nil, err
 	}
-	g.createPlayers(names)
+	g.createPlayers(seats)
 	if g.Players == nil {
 		return nil, ErrInvalidPlayers
 	}
+	err = g.createCards(shuffle)
+	if err != nil {
+		return nil, err
+	}
+	err = g.placeHomelands()
+	if err != nil {
+		return nil, err
+	}
+	g.recordSetup()
 	return g, nil
 }
 
-// createPlayers creates the Player objects in the game from a list of their names.  If it fails, Players will be nil.
-func (g *Game) createPlayers(names []string) {
-	if len(names) < 2 || len(names) > 4 {
+// createPlayers creates the Player objects in the game from their seats.  If it fails, Players will be nil.
+func (g *Game) createPlayers(seats []Seat) {
+	if len(seats) < 2 || len(seats) > 4 {
 		return
 	}
-
-	// Randomly order the dentitions, so that we can randomly assign one to each player.  
-	// Shuffle takes strings, so we'll have to convert the dentitions to ints.  
-	dentitions := []string{"2", "3", "4", "5"}
-	Shuffle(dentitions)
+	seated := make(map[int]bool)
+	for _, seat := range seats {
+		if seat.Dentition < 2 || seat.Dentition > 5 || seated[seat.Dentition] {
+			return
+		}
+		seated[seat.Dentition] = true
+	}
 
 	// create and name the players
-	players := make(SortablePlayerCollection, len(names))
-	for index, name := range names {
-		dentition, _ := strconv.Atoi(dentitions[index])
-		p := NewPlayer(name, dentition)
+	players := make(SortablePlayerCollection, len(seats))
+	for index, seat := range seats {
+		p := NewPlayer(seat.Name, seat.Dentition)
+		p.InheritanceTiles = make([]*InheritanceTile, len(g.Rules.InheritanceTiles))
+		copy(p.InheritanceTiles, g.Rules.InheritanceTiles)
 		players[index] = p
 		g.Tiles[p.HomelandTile.Key] = p.HomelandTile
 	}
@@ -112,21 +206,16 @@ func (g *Game) createPlayers(names []string) {
 }
 
 // createCards initializes the deck.
-func (g *Game) createCards() error {
-	var err error
-
-	// get the mutation cards
-	g.Cards, err = GetCards()
-	if err != nil {
-		return err
-	}
-
-	// get their keys and shuffle them
+func (g *Game) createCards(shuffle func(keys []string)) error {
+	// get the cards and their keys, and sort and shuffle the keys
+	g.Cards = make(map[string]*Card)
 	g.CardKeys = make([]string, 0)
-	for k, _ := range g.Cards {
+	for k, c := range g.Rules.Cards {
+		g.Cards[k] = c
 		g.CardKeys = append(g.CardKeys, k)
 	}
-	Shuffle(g.CardKeys)
+	sort.Strings(g.CardKeys)
+	shuffle(g.CardKeys)
 
 	// deal out the initial stacks
 	g.TriassicCardKeys = g.dealCards(len(g.Players) * 3)
@@ -142,7 +231,7 @@ func (g *Game) createCards() error {
 // dealCards "deals" keys from CardKeys to the various stacks.
 func (g *Game) dealCards(amount int) []string {
 	stack := make([]string, 0)
-	for i := 0; i < amount; i++ {
+	for i := 0; i < amount && len(g.CardKeys) > 0; i++ {
 		key := g.CardKeys[0]
 		stack = append(stack, key)
 		// was that the last card in the deck?
@@ -156,16 +245,15 @@ func (g *Game) dealCards(amount int) []string {
 }
 
 // createTiles initializes the tile stacks.
-func (g *Game) createTiles() error {
-	var err error
-
-	// get the tiles
-	g.Tiles, err = GetTiles()
-	if err != nil {
-		return err
+func (g *Game) createTiles(shuffle func(keys []string)) error {
+	// get the tiles; the map is copied, because the homeland tiles get added to it
+	g.Tiles = make(map[string]*Tile)
+	for k, t := range g.Rules.Tiles {
+		g.Tiles[k] = t
 	}
 
-	// get the keys for the two stacks of tiles and shuffle them
+	// get the keys for the two stacks of tiles, sort them so that a seeded shuffle always comes out the same,
+	// and shuffle them
 	g.MesozoicTileKeys = make([]string, 0)
 	g.CenozoicTileKeys = make([]string, 0)
 	for k, t := range g.Tiles {
@@ -175,8 +263,21 @@ func (g *Game) createTiles() error {
 			g.CenozoicTileKeys = append(g.CenozoicTileKeys, k)
 		}
 	}
-	Shuffle(g.MesozoicTileKeys)
-	Shuffle(g.CenozoicTileKeys)
+	sort.Strings(g.MesozoicTileKeys)
+	sort.Strings(g.CenozoicTileKeys)
+	shuffle(g.MesozoicTileKeys)
+	shuffle(g.CenozoicTileKeys)
+	return nil
+}
+
+// placeHomelands puts each player's homeland tile on the board.
+func (g *Game) placeHomelands() error {
+	for _, p := range g.Players {
+		_, err := g.Board.PlaceTileOnBoard(p.HomelandTile)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -196,8 +297,12 @@ type Player struct {
 	Color            string             // the player's color
 	Dentition        int                // how many teeth the player has
 	IsDinosaur       bool               // indicates if the player species are dinosaurs or mammals
-	Species          [][]*Animal        // the player's animals, indexed by silhouette (0-3)
+	Species          [][]*Animal        // the player's animals on the board, indexed by silhouette (0-3)
+	Genomes          []*DNASpec         // the genome of each species, indexed by silhouette; nil if there's no such species
+	SpeciesSizes     []int              // the size of each species, indexed by silhouette
 	Genes            int                // number of genes the player currently has
+	Score            int                // the player's score so far
+	CardKeys         []string           // keys of the cards the player has bought
 	AnimalTokens     []int              // number of animal tokens (of silhouettes 0-3) are in the player's supply
 	HomelandTile     *Tile              // the player's homeland tile
 	InheritanceTiles []*InheritanceTile // the player's supply of unused inheritance tiles
@@ -211,13 +316,15 @@ func NewPlayer(name string, dentition int) *Player {
 	p.Name = name
 	p.Dentition = dentition
 
-	colors := []string{"Red", "Orange", "Green", "White"}
-	p.Color = colors[p.Dentition-2]
-	p.IsDinosaur = p.Dentition == 2 || p.Dentition == 4
+	p.Color = PlayerColors[p.Dentition-2]
+	p.IsDinosaur = isDinosaurDentition(p.Dentition)
 	p.Species = make([][]*Animal, 4)
-	for i := 0; i < 3; i++ {
-		p.Species[i] = make([]*Animal, 8)
+	for i := 0; i < 4; i++ {
+		p.Species[i] = make([]*Animal, 0)
 	}
+	p.Genomes = make([]*DNASpec, 4)
+	p.SpeciesSizes = make([]int, 4)
+	p.CardKeys = make([]string, 0)
 	p.AnimalTokens = []int{8, 8, 8, 8}
 	p.InheritanceTiles = GetInheritanceTiles()
 
@@ -236,6 +343,7 @@ func NewPlayer(name string, dentition int) *Player {
 		panic(err)
 	}
 	b.Niche = niche
+	b.Requirements = MakeDNASpec("")
 	b.RedStar = true
 
 	switch dentition {
@@ -265,6 +373,11 @@ func NewPlayer(name string, dentition int) *Player {
 	return p
 }
 
+// HasSpecies tells you whether the player has a species with the given silhouette.
+func (p *Player) HasSpecies(silhouette int) bool {
+	return silhouette >= 0 && silhouette < len(p.Genomes) && p.Genomes[silhouette] != nil
+}
+
 // String formats a Player for display.
 func (p *Player) String() string {
 	return fmt.Sprintf("%v [%v/%v]", p.Name, p.Color, p.Dentition)
@@ -0,0 +1,255 @@
+package megafauna
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// The sides that a player can claim in a Lobby.  Dentitions 2 and 4 play dinosaurs, and 3 and 5 play mammals.
+const (
+	SideDinosaur = "Dinosaur"
+	SideMammal   = "Mammal"
+)
+
+var (
+	ErrLobbyFull        = errors.New("The lobby is full.")
+	ErrLobbyStarted     = errors.New("The game has already started.")
+	ErrEmptyName        = errors.New("Players must have a name.")
+	ErrNameTaken        = errors.New("That name is already taken.")
+	ErrNotInLobby       = errors.New("That player isn't in the lobby.")
+	ErrInvalidDentition = errors.New("Dentitions go from 2 to 5.")
+	ErrDentitionTaken   = errors.New("That dentition is already taken.")
+	ErrInvalidColor     = errors.New("Invalid color.")
+	ErrInvalidSide      = errors.New("Invalid side.")
+	ErrSideFull         = errors.New("That side is full.")
+	ErrPlayersNotReady  = errors.New("Not every player is ready.")
+	ErrNotEnoughPlayers = errors.New("A game needs at least 2 players.")
+)
+
+// Lobby is where players gather before a game.  Players join, optionally claim a dentition (or, which is the
+// same thing, a color) or a side, say they're ready, and then the game can start.  Anyone who hasn't claimed a
+// dentition is given a random one that's still free (on their side, if they claimed one).
+type Lobby struct {
+	Members   []*LobbyMember // in the order they joined
+	IsStarted bool
+}
+
+// LobbyMember is a player in a Lobby.
+type LobbyMember struct {
+	Name      string
+	Dentition int    // the claimed dentition, or 0
+	Side      string // the claimed side (SideDinosaur or SideMammal), or ""; set when a dentition is claimed
+	IsReady   bool
+}
+
+// NewLobby creates an empty Lobby.
+func NewLobby() *Lobby {
+	l := new(Lobby)
+	l.Members = make([]*LobbyMember, 0)
+	return l
+}
+
+// Join adds a player to the lobby.
+func (l *Lobby) Join(name string) error {
+	if l.IsStarted {
+		return ErrLobbyStarted
+	}
+	if name == "" {
+		return ErrEmptyName
+	}
+	if l.GetMember(name) != nil {
+		return ErrNameTaken
+	}
+	if len(l.Members) == 4 {
+		return ErrLobbyFull
+	}
+	l.Members = append(l.Members, &LobbyMember{Name: name})
+	return nil
+}
+
+// Leave takes a player out of the lobby.
+func (l *Lobby) Leave(name string) error {
+	if l.IsStarted {
+		return ErrLobbyStarted
+	}
+	for i, m := range l.Members {
+		if m.Name == name {
+			l.Members = append(l.Members[:i:i], l.Members[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotInLobby
+}
+
+// GetMember returns the member of the lobby with the given name, or nil.
+func (l *Lobby) GetMember(name string) *LobbyMember {
+	for _, m := range l.Members {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// ClaimDentition claims a dentition, and with it a color and a side, for a player.  A dentition of 0 gives up
+// the player's claim.
+func (l *Lobby) ClaimDentition(name string, dentition int) error {
+	m, err := l.claimant(name)
+	if err != nil {
+		return err
+	}
+	if dentition == 0 {
+		m.Dentition = 0
+		m.Side = ""
+		return nil
+	}
+	if dentition < 2 || dentition > 5 {
+		return ErrInvalidDentition
+	}
+	for _, other := range l.Members {
+		if other != m && other.Dentition == dentition {
+			return ErrDentitionTaken
+		}
+	}
+	side := dentitionSide(dentition)
+	if m.Side != side && l.sideCount(side, m) == 2 {
+		return ErrSideFull
+	}
+	m.Dentition = dentition
+	m.Side = side
+	return nil
+}
+
+// ClaimColor claims the dentition that goes with a color (see PlayerColors) for a player.
+func (l *Lobby) ClaimColor(name string, color string) error {
+	for i, c := range PlayerColors {
+		if c == color {
+			return l.ClaimDentition(name, i+2)
+		}
+	}
+	return ErrInvalidColor
+}
+
+// ClaimSide claims a side for a player, giving up any dentition they've claimed.  A side of "" gives up the
+// player's claim.
+func (l *Lobby) ClaimSide(name string, side string) error {
+	m, err := l.claimant(name)
+	if err != nil {
+		return err
+	}
+	if side != "" && side != SideDinosaur && side != SideMammal {
+		return ErrInvalidSide
+	}
+	if side != "" && l.sideCount(side, m) == 2 {
+		return ErrSideFull
+	}
+	m.Dentition = 0
+	m.Side = side
+	return nil
+}
+
+// SetReady says whether a player is ready to start.
+func (l *Lobby) SetReady(name string, ready bool) error {
+	m, err := l.claimant(name)
+	if err != nil {
+		return err
+	}
+	m.IsReady = ready
+	return nil
+}
+
+// CanStart returns nil if the game can start, or an error saying why it can't.
+func (l *Lobby) CanStart() error {
+	if l.IsStarted {
+		return ErrLobbyStarted
+	}
+	if len(l.Members) < 2 {
+		return ErrNotEnoughPlayers
+	}
+	for _, m := range l.Members {
+		if !m.IsReady {
+			return ErrPlayersNotReady
+		}
+	}
+	return nil
+}
+
+// Start creates a Game from a RuleSet for the players in the lobby, seating them at the dentitions they
+// claimed and giving everyone else a random dentition that's free.
+func (l *Lobby) Start(rules *RuleSet) (*Game, error) {
+	if err := l.CanStart(); err != nil {
+		return nil, err
+	}
+	g, err := NewGameWithSeats(rules, l.Seats())
+	if err != nil {
+		return nil, err
+	}
+	l.IsStarted = true
+	return g, nil
+}
+
+// Seats assigns a dentition to every player in the lobby: the one they claimed if they claimed one, or else
+// a random free one (on their side, if they claimed a side).  The players who claimed a side are seated
+// before the ones who didn't, so that their side still has room for them.
+func (l *Lobby) Seats() []Seat {
+	seats := make([]Seat, len(l.Members))
+	taken := make(map[int]bool)
+	for i, m := range l.Members {
+		seats[i].Name = m.Name
+		if m.Dentition != 0 {
+			seats[i].Dentition = m.Dentition
+			taken[m.Dentition] = true
+		}
+	}
+	for _, side := range []string{SideDinosaur, SideMammal, ""} {
+		for i, m := range l.Members {
+			if m.Dentition == 0 && m.Side == side {
+				seats[i].Dentition = randomFreeDentition(side, taken)
+				taken[seats[i].Dentition] = true
+			}
+		}
+	}
+	return seats
+}
+
+// claimant returns the member with the given name, if they can still make claims.
+func (l *Lobby) claimant(name string) (*LobbyMember, error) {
+	if l.IsStarted {
+		return nil, ErrLobbyStarted
+	}
+	m := l.GetMember(name)
+	if m == nil {
+		return nil, ErrNotInLobby
+	}
+	return m, nil
+}
+
+// sideCount returns the number of members other than m who have claimed a side.
+func (l *Lobby) sideCount(side string, m *LobbyMember) int {
+	count := 0
+	for _, other := range l.Members {
+		if other != m && other.Side == side {
+			count++
+		}
+	}
+	return count
+}
+
+// dentitionSide returns the side that the player with the given dentition plays.
+func dentitionSide(dentition int) string {
+	if isDinosaurDentition(dentition) {
+		return SideDinosaur
+	}
+	return SideMammal
+}
+
+// randomFreeDentition returns a random dentition that isn't taken, on the given side if side isn't "".
+func randomFreeDentition(side string, taken map[int]bool) int {
+	free := make([]int, 0)
+	for d := 2; d <= 5; d++ {
+		if !taken[d] && (side == "" || dentitionSide(d) == side) {
+			free = append(free, d)
+		}
+	}
+	return free[rand.Intn(len(free))]
+}
@@ -0,0 +1,188 @@
+package megafauna
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// MCTSBot is a Bot that chooses its action by Monte Carlo tree search.  Since the players can't see the order
+// of the stacks, it uses information set MCTS: each iteration makes a game from its view with the unseen cards
+// and tiles dealt out at random (see NewGameFromView), walks down a tree of actions that is shared by every
+// such determinization, choosing among the actions that are legal in this one, adds an action to the tree,
+// and plays the game out at random.  Each action in the tree is credited with a win when the player who took
+// it wins the playout.  Like the GreedyBot, it has to be given the rules the game is played with.
+type MCTSBot struct {
+	Rules       *RuleSet
+	Rand        *rand.Rand
+	Iterations  int           // the most iterations to run for each decision; 0 for no limit
+	TimeLimit   time.Duration // the longest to spend on each decision; 0 for no limit
+	Exploration float64       // the exploration constant in the UCB formula
+}
+
+// The defaults for an MCTSBot's budget and exploration constant.
+const (
+	DefaultMCTSIterations  = 1000
+	DefaultMCTSExploration = 0.7
+)
+
+// NewMCTSBot creates an MCTSBot for games played with a RuleSet that runs up to the given number of iterations
+// and spends up to the given time on each decision.  If both are 0, it runs DefaultMCTSIterations iterations.
+func NewMCTSBot(rules *RuleSet, r *rand.Rand, iterations int, timeLimit time.Duration) *MCTSBot {
+	if iterations == 0 && timeLimit == 0 {
+		iterations = DefaultMCTSIterations
+	}
+	return &MCTSBot{Rules: rules, Rand: r, Iterations: iterations, TimeLimit: timeLimit, Exploration: DefaultMCTSExploration}
+}
+
+// mctsNode is an action in an MCTSBot's search tree.
+type mctsNode struct {
+	action    Action // the action that leads to this node from its parent; empty for the root
+	parent    *mctsNode
+	children  []*mctsNode
+	visits    int     // the number of iterations that went through this node
+	available int     // the number of iterations in which this node's action was legal
+	wins      float64 // the number of those iterations won by the player who took the action
+}
+
+// ChooseAction searches for the best of the legal actions, and chooses the one that was visited most.
+func (b *MCTSBot) ChooseAction(view *GameView, legal []*Action) *Action {
+	if len(legal) <= 1 {
+		if len(legal) == 0 {
+			return nil
+		}
+		return legal[0]
+	}
+
+	root := new(mctsNode)
+	var deadline time.Time
+	if b.TimeLimit > 0 {
+		deadline = time.Now().Add(b.TimeLimit)
+	}
+	for i := 0; b.Iterations == 0 || i < b.Iterations; i++ {
+		if b.TimeLimit > 0 && time.Now().After(deadline) {
+			break
+		}
+		if err := b.iterate(view, root); err != nil {
+			break
+		}
+	}
+
+	var best *mctsNode
+	for _, child := range root.children {
+		if best == nil || child.visits > best.visits {
+			best = child
+		}
+	}
+	if best == nil {
+		return legal[b.Rand.Intn(len(legal))]
+	}
+	for _, a := range legal {
+		if *a == best.action {
+			return a
+		}
+	}
+	return legal[0]
+}
+
+// iterate runs one iteration of the search from the root, on a game made from the view.  Making the game
+// doesn't replay its history or shuffle anything but b.Rand, so an iteration costs the same late in a game as
+// early on, and the same Rand always searches the same way.
+func (b *MCTSBot) iterate(view *GameView, root *mctsNode) error {
+	g, err := NewGameFromView(b.Rules, view, b.Rand)
+	if err != nil {
+		return err
+	}
+
+	// select and expand
+	node := root
+	for !g.IsOver {
+		legal := g.LegalActions(g.GetActivePlayer().Dentition)
+		untried := node.untried(legal)
+		if len(untried) > 0 {
+			a := untried[b.Rand.Intn(len(untried))]
+			child := &mctsNode{action: *a, parent: node}
+			node.children = append(node.children, child)
+			node.markAvailable(legal)
+			if err := g.Apply(a); err != nil {
+				return err
+			}
+			node = child
+			break
+		}
+		node = node.selectChild(legal, b.Exploration)
+		if err := g.Apply(&node.action); err != nil {
+			return err
+		}
+	}
+
+	// play out
+	for !g.IsOver {
+		legal := g.LegalActions(g.GetActivePlayer().Dentition)
+		if err := g.Apply(legal[b.Rand.Intn(len(legal))]); err != nil {
+			return err
+		}
+	}
+
+	// back up
+	winner := g.Winner().Dentition
+	for ; node != nil; node = node.parent {
+		node.visits++
+		if node.action.Dentition == winner {
+			node.wins++
+		}
+	}
+	return nil
+}
+
+// untried returns the legal actions that n has no child for.
+func (n *mctsNode) untried(legal []*Action) []*Action {
+	untried := make([]*Action, 0)
+	for _, a := range legal {
+		if n.child(a) == nil {
+			untried = append(untried, a)
+		}
+	}
+	return untried
+}
+
+// child returns n's child for an action, or nil.
+func (n *mctsNode) child(a *Action) *mctsNode {
+	for _, c := range n.children {
+		if c.action == *a {
+			return c
+		}
+	}
+	return nil
+}
+
+// markAvailable counts an iteration in which the legal actions were available, for each of n's children
+// that they have.
+func (n *mctsNode) markAvailable(legal []*Action) {
+	for _, a := range legal {
+		if c := n.child(a); c != nil {
+			c.available++
+		}
+	}
+}
+
+// selectChild chooses the child of n, among those whose actions are legal, with the highest upper confidence
+// bound, and counts the iteration as one in which they were all available.  A child that has never been
+// visited (because the iteration that added it failed) has no bound, so it's chosen first.  Every legal action
+// must have a child.
+func (n *mctsNode) selectChild(legal []*Action, exploration float64) *mctsNode {
+	n.markAvailable(legal)
+	var best *mctsNode
+	bestBound := 0.0
+	for _, a := range legal {
+		c := n.child(a)
+		if c.visits == 0 {
+			return c
+		}
+		bound := c.wins/float64(c.visits) + exploration*math.Sqrt(math.Log(float64(c.available))/float64(c.visits))
+		if best == nil || bound > bestBound {
+			best, bestBound = c, bound
+		}
+	}
+	return best
+}
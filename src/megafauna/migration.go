@@ -0,0 +1,124 @@
+package megafauna
+
+import (
+	"errors"
+)
+
+var (
+	ErrHabitatNotFound = errors.New("Habitat not found.")
+	ErrNoMigrationPath = errors.New("There's no way for the species to migrate there.")
+)
+
+// Migration moves a species from a habitat to one of its AdjacentHabitats, one step at a time.  What a step
+// costs depends on the habitat it goes into and on the species' genome:
+//
+//   - A sea habitat (one with a sea biome that isn't also land) can only be entered by a Marine species.
+//   - An orogeny habitat is a mountain barrier, which takes two steps to cross, except for a Speedy species.
+//   - Every other habitat, including an empty one, takes one step.
+//
+// The habitat a species starts from costs nothing, whatever it is.
+
+// migrationCost returns the number of steps it takes a species with the given genome to move into h, or -1
+// if it can't.
+func migrationCost(genome *DNASpec, h *Habitat) int {
+	if h.Biome != nil && h.Biome.IsSeaOnly() && genome.GetDNAValue("M") == 0 {
+		return -1
+	}
+	if h.IsOrogeny && genome.GetDNAValue("S") == 0 {
+		return 2
+	}
+	return 1
+}
+
+// MigrationReach returns the number of steps it takes a species with the given genome to migrate to each
+// habitat it can reach in at most the given number of steps from the nearest of the habitats with the given
+// keys, by habitat key.  The starting habitats are included, at 0 steps.
+func (b *Board) MigrationReach(genome *DNASpec, steps int, fromKeys ...string) (map[string]int, error) {
+	costs, _, err := b.migrate(genome, steps, fromKeys)
+	if err != nil {
+		return nil, err
+	}
+	reach := make(map[string]int)
+	for h, cost := range costs {
+		reach[h.Key] = cost
+	}
+	return reach, nil
+}
+
+// MigrationPath returns the keys of the habitats on the shortest migration path for a species with the given
+// genome from one habitat to another, starting with fromKey and ending with toKey.  Ties between equally
+// short paths are always broken the same way.  It returns ErrNoMigrationPath if the species can't get there
+// at all.
+func (b *Board) MigrationPath(genome *DNASpec, fromKey, toKey string) ([]string, error) {
+	to := b.HabitatMap[toKey]
+	if to == nil {
+		return nil, ErrHabitatNotFound
+	}
+	costs, previous, err := b.migrate(genome, -1, []string{fromKey})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := costs[to]; !ok {
+		return nil, ErrNoMigrationPath
+	}
+	path := make([]string, 0)
+	for h := to; h != nil; h = previous[h] {
+		path = append(path, h.Key)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// migrate finds the cheapest way for a species with the given genome to migrate to every habitat that it can
+// reach within the given number of steps (or any number, if steps is negative) from the habitats with the
+// given keys.  It returns the cost of getting to each one, and the habitat each one is reached from (nil
+// for the starting habitats).  Since every habitat costs one or two steps, this is Dijkstra's algorithm
+// with a queue for each cost.
+func (b *Board) migrate(genome *DNASpec, steps int, fromKeys []string) (map[*Habitat]int, map[*Habitat]*Habitat, error) {
+	costs := make(map[*Habitat]int)
+	previous := make(map[*Habitat]*Habitat)
+	queues := [][]*Habitat{make([]*Habitat, 0)}
+	for _, key := range fromKeys {
+		h := b.HabitatMap[key]
+		if h == nil {
+			return nil, nil, ErrHabitatNotFound
+		}
+		if _, ok := costs[h]; !ok {
+			costs[h] = 0
+			previous[h] = nil
+			queues[0] = append(queues[0], h)
+		}
+	}
+
+	done := make(map[*Habitat]bool)
+	for cost := 0; cost < len(queues); cost++ {
+		for i := 0; i < len(queues[cost]); i++ {
+			h := queues[cost][i]
+			if done[h] || costs[h] != cost {
+				continue
+			}
+			done[h] = true
+			for _, adjacent := range h.AdjacentHabitats {
+				if adjacent == nil || done[adjacent] {
+					continue
+				}
+				step := migrationCost(genome, adjacent)
+				if step < 0 || (steps >= 0 && cost+step > steps) {
+					continue
+				}
+				if old, ok := costs[adjacent]; ok && old <= cost+step {
+					continue
+				}
+				costs[adjacent] = cost + step
+				previous[adjacent] = h
+				for len(queues) <= cost+step {
+					queues = append(queues, make([]*Habitat, 0))
+				}
+				queues[cost+step] = append(queues[cost+step], adjacent)
+			}
+		}
+	}
+	return costs, previous, nil
+}
@@ -43,3 +43,14 @@ func MakeNiche(spec string) (n *Niche, err error) {
 	err = MakeNicheError{spec}
 	return
 }
+
+// String returns the spec that MakeNiche would make the Niche from, e.g. "size", "3" or "B".
+func (n *Niche) String() string {
+	switch {
+	case n.Size:
+		return "size"
+	case n.Dentition != 0:
+		return strconv.Itoa(n.Dentition)
+	}
+	return n.DNA
+}
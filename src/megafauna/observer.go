@@ -0,0 +1,93 @@
+package megafauna
+
+import (
+	"reflect"
+)
+
+// GameEventType is the kind of a GameEvent.
+type GameEventType string
+
+// The types of GameEvent.
+const (
+	EventCardDrawn      GameEventType = "CardDrawn"
+	EventCardBought     GameEventType = "CardBought"
+	EventTilePlaced     GameEventType = "TilePlaced"
+	EventTileDisplaced  GameEventType = "TileDisplaced"
+	EventAnimalPlaced   GameEventType = "AnimalPlaced"
+	EventAnimalCulled   GameEventType = "AnimalCulled"
+	EventSpeciesExtinct GameEventType = "SpeciesExtinct"
+	EventClimateChanged GameEventType = "ClimateChanged"
+	EventScoreChanged   GameEventType = "ScoreChanged"
+	EventGameOver       GameEventType = "GameOver"
+)
+
+// GameEvent is something that happened in a Game, as told to its Observers.  Which fields are used depends
+// on Type.
+type GameEvent struct {
+	Type         GameEventType
+	Dentition    int    `json:",omitempty"` // the player involved, if any; 1 for an immigrant animal; the winner for GameOver
+	CardKey      string `json:",omitempty"` // CardDrawn, CardBought
+	TileKey      string `json:",omitempty"` // TilePlaced, TileDisplaced (the tile that went to the tarpit)
+	HabitatKey   string `json:",omitempty"` // TilePlaced, TileDisplaced, AnimalPlaced, AnimalCulled
+	Slot         string `json:",omitempty"` // AnimalPlaced, AnimalCulled
+	Silhouette   int    // AnimalPlaced, AnimalCulled and SpeciesExtinct, for a player's species
+	ImmigrantKey string `json:",omitempty"` // AnimalPlaced and AnimalCulled, for an immigrant
+	Climate      int    // ClimateChanged: the new climate
+	Score        int    // ScoreChanged: the new score
+}
+
+// Observer is told about everything that happens in a Game, as it happens, so that UIs, loggers and
+// statistics can follow along without comparing snapshots.  Events are only sent for what happens once the
+// game has been set up.
+type Observer interface {
+	Observe(g *Game, e *GameEvent)
+}
+
+// ObserverFunc makes an Observer out of a function.
+type ObserverFunc func(g *Game, e *GameEvent)
+
+// Observe calls f.
+func (f ObserverFunc) Observe(g *Game, e *GameEvent) {
+	f(g, e)
+}
+
+// AddObserver adds an Observer to the game.  Observers aren't copied when a game is cloned.
+func (g *Game) AddObserver(o Observer) {
+	g.observers = append(g.observers, o)
+}
+
+// RemoveObserver removes an Observer from the game.  Observers are found by comparing them, so an
+// ObserverFunc, which can't be compared, can't be removed.
+func (g *Game) RemoveObserver(o Observer) {
+	if !reflect.TypeOf(o).Comparable() {
+		return
+	}
+	for i, other := range g.observers {
+		if other == o {
+			g.observers = append(g.observers[:i:i], g.observers[i+1:]...)
+			return
+		}
+	}
+}
+
+// notify tells the game's observers about an event.
+func (g *Game) notify(e *GameEvent) {
+	for _, o := range g.observers {
+		o.Observe(g, e)
+	}
+}
+
+// notifyAnimal tells the game's observers that an animal was placed in or culled from a slot of a biome.
+func (g *Game) notifyAnimal(t GameEventType, b *Biome, slot string, a *Animal) {
+	e := &GameEvent{Type: t, Dentition: a.Dentition, HabitatKey: b.Key, Slot: slot, Silhouette: a.Silhouette}
+	if a.ImmigrantTile != nil {
+		e.ImmigrantKey = a.ImmigrantTile.Key
+	}
+	g.notify(e)
+}
+
+// changeClimate warms (a positive change) or cools the climate, and tells the observers.
+func (g *Game) changeClimate(change int) {
+	g.Climate += change
+	g.notify(&GameEvent{Type: EventClimateChanged, Climate: g.Climate})
+}
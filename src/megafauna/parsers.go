@@ -2,16 +2,71 @@ package megafauna
 
 import (
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"strings"
 )
 
-// Parseable types implement a Parse method.  I'm not really sure that we need a Parseable interface per se; I
-// can't really think of a useful function that would take a Parseable as an argument.  Maybe there could be 
-// an Init(slices []Parseable, filenames []string) that parsed all of the data at startup. 
+// Parseable types implement a Parse method.  Every source of rule data - cards, tiles, inheritance tiles and
+// latitudes - has a Parseable, which is what lets Init parse a complete RuleSet from a map of named sources.
 type Parseable interface {
 	Parse(io.Reader) error
 }
 
+// parseFunc makes a Parseable out of a function.
+type parseFunc func(io.Reader) error
+
+// Parse calls f.
+func (f parseFunc) Parse(r io.Reader) error {
+	return f(r)
+}
+
+// CardMap is a map of Cards, which is parsed from more than one source.
+type CardMap map[string]*Card
+
+// Source returns the Parseable that parses the named source of card data, MutationCardsFile or
+// GenotypeCardsFile, into the map.  It returns nil for any other name.
+func (cards CardMap) Source(name string) Parseable {
+	switch name {
+	case MutationCardsFile:
+		return parseFunc(func(r io.Reader) error { return parseMutationCards(r, cards, nil) })
+	case GenotypeCardsFile:
+		return parseFunc(func(r io.Reader) error { return parseGenotypeCards(r, cards, nil) })
+	}
+	return nil
+}
+
+// TileMap is a map of Tiles, which is parsed from more than one source.
+type TileMap map[string]*Tile
+
+// Source returns the Parseable that parses the named source of tile data, BiomeTilesFile or
+// ImmigrantTilesFile, into the map.  It returns nil for any other name.
+func (tiles TileMap) Source(name string) Parseable {
+	switch name {
+	case BiomeTilesFile:
+		return parseFunc(func(r io.Reader) error { return parsebiomeTiles(r, tiles, nil) })
+	case ImmigrantTilesFile:
+		return parseFunc(func(r io.Reader) error { return parseimmigrantTiles(r, tiles, nil) })
+	}
+	return nil
+}
+
+// InheritanceTileList is a Parseable of InheritanceTiles.
+type InheritanceTileList []*InheritanceTile
+
+// Parse parses the inheritance tile data in r and appends the tiles to the list.
+func (tiles *InheritanceTileList) Parse(r io.Reader) error {
+	result := []*InheritanceTile(*tiles)
+	err := parseInheritanceTiles(r, &result, nil)
+	if err != nil {
+		return err
+	}
+	*tiles = result
+	return nil
+}
+
 // LatitudeMap is a Parseable of Latitudes.
 type LatitudeMap map[string]*Latitude
 
@@ -35,3 +90,77 @@ func (latitudes LatitudeMap) Parse(r io.Reader) error {
 	}
 	return nil
 }
+
+// Names of the data files that the FS variants of GetCards, GetTiles and GetInheritanceTiles look for.  These
+// are also the names of the sources that Init accepts.
+const (
+	MutationCardsFile    = "mutation_cards.csv"
+	GenotypeCardsFile    = "genotype_cards.csv"
+	BiomeTilesFile       = "biome_tiles.csv"
+	ImmigrantTilesFile   = "immigrant_tiles.csv"
+	InheritanceTilesFile = "inheritance_tiles.csv"
+	LatitudesFile        = "latitudes.csv"
+)
+
+// openDataFile opens the named file in fsys.  If fsys is nil or the file doesn't exist, it returns a reader
+// over the embedded data instead, so that a data set only needs to contain the files it changes.
+func openDataFile(fsys fs.FS, name string, embedded string) (io.ReadCloser, error) {
+	if fsys != nil {
+		f, err := fsys.Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return io.NopCloser(strings.NewReader(embedded)), nil
+}
+
+// readRecords reads CSV records from r and calls parse for each one.  Any problem is returned as a *DataError
+// giving the file and line it was found on.  If report is nil, readRecords stops at the first problem;
+// otherwise it adds each problem to the report and carries on with the next record.
+func readRecords(r io.Reader, file string, fieldCount int, report *ValidationReport, parse func(record []string) error) error {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			// a malformed file can't be read any further, so this is always the last problem
+			e := &DataError{File: file, Err: err}
+			if parseErr, ok := err.(*csv.ParseError); ok {
+				e.Line = parseErr.Line
+				e.Err = parseErr.Err
+			}
+			if report == nil {
+				return e
+			}
+			report.add(e)
+			return nil
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		if len(record) != fieldCount {
+			err = fmt.Errorf("Record has %v fields; expected %v.", len(record), fieldCount)
+		} else {
+			err = parse(record)
+		}
+		if err == nil {
+			continue
+		}
+
+		e, ok := err.(*DataError)
+		if !ok {
+			e = &DataError{Err: err}
+		}
+		e.File = file
+		e.Line = line
+		if report == nil {
+			return e
+		}
+		report.add(e)
+	}
+}
@@ -0,0 +1,167 @@
+package megafauna
+
+import (
+	"fmt"
+)
+
+// CullForecast is what a cull would do to the board, as predicted by PredictCull.
+type CullForecast struct {
+	Habitats map[string][]*AnimalFate // what would happen to each animal in the culled biomes, by habitat key
+	Extinct  map[int][]int            // the silhouettes of the species that would go extinct, by dentition
+}
+
+// AnimalFate is what a cull would do to one animal, and why.
+type AnimalFate struct {
+	Animal   *AnimalView
+	Slot     string // SlotHerbivore, SlotRooter or SlotPredator
+	Survives bool
+	//
+	// the parts of a herbivore's or rooter's score in its contest (see ContestEntry); all 0 for
+	// a predator
+	//
+	Suitability int
+	Niche       int
+	Dentition   int
+	Reason      string // why the animal survives or dies, in English
+}
+
+// PredictCull predicts what Cull would do with the same latitudes, by running it on a clone of the game, and
+// explains the contests in each biome.  The game itself isn't changed.
+func (g *Game) PredictCull(latitudeKeys ...string) (*CullForecast, error) {
+	clone, err := g.Clone()
+	if err != nil {
+		return nil, err
+	}
+	f := &CullForecast{Habitats: make(map[string][]*AnimalFate), Extinct: make(map[int][]int)}
+	animals := make(map[*Animal]*AnimalFate)
+	for _, b := range clone.biomesInLatitudes(latitudeKeys) {
+		fates := explainCull(b)
+		for _, fate := range fates {
+			animals[fate.animal] = fate.AnimalFate
+			f.Habitats[b.Key] = append(f.Habitats[b.Key], fate.AnimalFate)
+		}
+	}
+
+	species := make([][]bool, len(clone.Players))
+	for i, p := range clone.Players {
+		for s := range p.Genomes {
+			species[i] = append(species[i], p.HasSpecies(s))
+		}
+	}
+	clone.Cull(latitudeKeys...)
+	for _, b := range clone.Board.Biomes() {
+		for _, a := range b.Animals() {
+			if fate := animals[a]; fate != nil {
+				fate.Survives = true
+			}
+		}
+	}
+	for i, p := range clone.Players {
+		for s, had := range species[i] {
+			if had && !p.HasSpecies(s) {
+				f.Extinct[p.Dentition] = append(f.Extinct[p.Dentition], s)
+			}
+		}
+	}
+	return f, nil
+}
+
+// animalFate is an AnimalFate and the animal it's about.
+type animalFate struct {
+	*AnimalFate
+	animal *Animal
+}
+
+// explainCull works out why each animal in b would survive a cull or not, in the same way as cullSurvivors.
+func explainCull(b *Biome) []*animalFate {
+	data := b.Tile.BiomeData
+	fates := make([]*animalFate, 0)
+	prey := make([]*Animal, 0)
+	herbivores := &HerbivoreContest{Animals: copyAnimals(b.Herbivore), Requirements: data.Requirements, Niche: data.Niche}
+	fates, prey = explainHerbivoreContest(herbivores, SlotHerbivore, fates, prey)
+	requirements := data.RooterRequirements
+	if requirements == nil {
+		requirements = data.Requirements
+	}
+	rooters := &HerbivoreContest{Animals: copyAnimals(b.Rooter), Requirements: requirements, Niche: data.Niche}
+	fates, prey = explainHerbivoreContest(rooters, SlotRooter, fates, prey)
+
+	for _, predator := range b.Predator {
+		fate := &animalFate{&AnimalFate{Animal: animalViews([]*Animal{predator})[0], Slot: SlotPredator}, predator}
+		fate.Reason = explainPredator(predator, b.Predator, prey)
+		fates = append(fates, fate)
+	}
+	return fates
+}
+
+// explainHerbivoreContest runs a herbivore or rooter contest and explains how each animal in it does, adding
+// their fates to fates and the winner, if there is one, to prey.
+func explainHerbivoreContest(h *HerbivoreContest, slot string, fates []*animalFate, prey []*Animal) ([]*animalFate, []*Animal) {
+	contest := "herbivore contest"
+	if slot == SlotRooter {
+		contest = "rooter contest"
+	}
+	result := h.Resolve()
+	for _, e := range result.Entries {
+		fate := &animalFate{&AnimalFate{Animal: animalViews([]*Animal{e.Animal})[0], Slot: slot}, e.Animal}
+		fate.Suitability, fate.Niche, fate.Dentition = e.Suitability, e.Niche, e.Dentition
+		switch e.Eliminated {
+		case "":
+			fate.Reason = fmt.Sprintf("wins the %v with %v (suitability %v, niche %v, dentition %v)", contest,
+				e.Score, e.Suitability, e.Niche, e.Dentition)
+		case EliminatedUnsuitable:
+			fate.Reason = "can't feed on the biome's requirements"
+		case EliminatedOutscored:
+			fate.Reason = fmt.Sprintf("loses the %v to %v, %v to %v", contest, describeAnimal(result.Winner),
+				result.Entries[0].Score, e.Score)
+		}
+		fates = append(fates, fate)
+	}
+	if result.Winner != nil {
+		prey = append(prey, result.Winner)
+	}
+	return fates, prey
+}
+
+// explainPredator explains whether a predator catches any of the prey, in competition with the other
+// predators, in the same way as CarnivoreContest.FindWinners.
+func explainPredator(predator *Animal, predators []*Animal, prey []*Animal) string {
+	if len(prey) == 0 {
+		return "has no prey, since no herbivore or rooter survives"
+	}
+	var lostTo []*Animal
+	var lost []*Animal
+	for _, p := range prey {
+		if !predator.canFeedOn(p) {
+			continue
+		}
+		feeders := make([]*Animal, 0)
+		for _, c := range predators {
+			if c.canFeedOn(p) {
+				feeders = append(feeders, c)
+			}
+		}
+		winner := findWinningCarnivore(feeders)
+		if winner == predator {
+			return fmt.Sprintf("catches %v", describeAnimal(p))
+		}
+		lost = append(lost, p)
+		lostTo = append(lostTo, winner)
+	}
+	if len(lost) == 0 {
+		return "can't catch any of the surviving prey"
+	}
+	return fmt.Sprintf("loses %v to %v, which has more P or fewer teeth", describeAnimal(lost[0]), describeAnimal(lostTo[0]))
+}
+
+// describeAnimal names an animal in English: its player's color and its silhouette, or the immigrant tile it
+// came from.
+func describeAnimal(a *Animal) string {
+	if a.ImmigrantTile != nil || a.Dentition < 2 || a.Dentition > 5 {
+		if a.ImmigrantTile == nil {
+			return "an immigrant"
+		}
+		return fmt.Sprintf("the immigrant %v", a.ImmigrantTile.Key)
+	}
+	return fmt.Sprintf("%v species %v", PlayerColors[a.Dentition-2], a.Silhouette)
+}
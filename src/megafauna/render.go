@@ -0,0 +1,175 @@
+package megafauna
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// renderCellWidth is the width of the inside of a habitat's cell in Render's grid.
+const renderCellWidth = 16
+
+// Render draws the board as an ASCII grid, for debugging.  Each habitat is drawn as a cell in the position
+// its adjacent habitats give it, so the extra row of the Tropics comes out below the rest.  A cell shows:
+//
+//	T2 8 ^           the habitat's key, printed climax number, and ^ if it's an orogeny habitat
+//	Fern Understory  the biome's title, if there's a biome
+//	climax 58        the biome's climax number
+//	P: R0 i3         the predators: the first letter of the player's color and the silhouette (0-3),
+//	H: O1            or i and the size for an immigrant; then the herbivores,
+//	R:               and the rooters
+func (b *Board) Render(w io.Writer) error {
+	grid, columns := b.grid()
+	border := strings.Repeat("+"+strings.Repeat("-", renderCellWidth), columns) + "+\n"
+	blank := strings.Repeat(" ", renderCellWidth)
+
+	for _, row := range grid {
+		if _, err := fmt.Fprintf(w, "%v\n%v", b.rowLabel(row), border); err != nil {
+			return err
+		}
+		cells := make([][]string, columns)
+		lines := 0
+		for col, h := range row {
+			if h != nil {
+				cells[col] = renderHabitat(h)
+				lines = len(cells[col])
+			}
+		}
+		for line := 0; line < lines; line++ {
+			var sb strings.Builder
+			for _, cell := range cells {
+				sb.WriteString("|")
+				if cell == nil {
+					sb.WriteString(blank)
+				} else {
+					sb.WriteString(fitCell(cell[line]))
+				}
+			}
+			sb.WriteString("|\n")
+			if _, err := io.WriteString(w, sb.String()); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, border); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grid lays out the habitats on a grid, following their adjacent habitats from the first habitat of the first
+// row.  Habitats that can't be reached from there are laid out the same way from the first of them, below the
+// rest.  It returns the rows of the grid, which have nils where there's no habitat, and the number of columns.
+func (b *Board) grid() ([][]*Habitat, int) {
+	var grid [][]*Habitat
+	columns := 0
+	placed := make(map[*Habitat]bool)
+	for _, row := range b.Habitats {
+		for _, h := range row {
+			if placed[h] {
+				continue
+			}
+			part := layOut(h, placed)
+			grid = append(grid, part...)
+			columns = max(columns, len(part[0]))
+		}
+	}
+	for i, row := range grid {
+		grid[i] = append(row, make([]*Habitat, columns-len(row))...)
+	}
+	return grid, columns
+}
+
+// layOut lays out the habitats that can be reached from start on a grid, following their adjacent habitats, and
+// marks them as placed.
+func layOut(start *Habitat, placed map[*Habitat]bool) [][]*Habitat {
+	type position struct{ row, col int }
+	positions := map[*Habitat]position{start: {}}
+	placed[start] = true
+	queue := []*Habitat{start}
+	moves := []position{MapDirectionN: {-1, 0}, MapDirectionE: {0, 1}, MapDirectionS: {1, 0}, MapDirectionW: {0, -1}}
+	minRow, minCol, maxRow, maxCol := 0, 0, 0, 0
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		p := positions[h]
+		for dir, adjacent := range h.AdjacentHabitats {
+			if adjacent == nil || placed[adjacent] {
+				continue
+			}
+			q := position{p.row + moves[dir].row, p.col + moves[dir].col}
+			positions[adjacent] = q
+			placed[adjacent] = true
+			queue = append(queue, adjacent)
+			minRow, maxRow = min(minRow, q.row), max(maxRow, q.row)
+			minCol, maxCol = min(minCol, q.col), max(maxCol, q.col)
+		}
+	}
+
+	grid := make([][]*Habitat, maxRow-minRow+1)
+	for i := range grid {
+		grid[i] = make([]*Habitat, maxCol-minCol+1)
+	}
+	for h, p := range positions {
+		grid[p.row-minRow][p.col-minCol] = h
+	}
+	return grid
+}
+
+// rowLabel returns the name and key of the latitude of the habitats in a row of the grid.
+func (b *Board) rowLabel(row []*Habitat) string {
+	for _, h := range row {
+		if h == nil {
+			continue
+		}
+		for key, lat := range b.LatitudeMap {
+			if key == "O" {
+				continue
+			}
+			for _, other := range lat.Habitats {
+				if other == h {
+					return fmt.Sprintf("%v (%v)", lat.Name, lat.Key)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// renderHabitat returns the lines of a habitat's cell.
+func renderHabitat(h *Habitat) []string {
+	header := fmt.Sprintf("%v %v", h.Key, h.ClimaxNumber)
+	if h.IsOrogeny {
+		header += " ^"
+	}
+	lines := []string{header, "", "", "P:", "H:", "R:"}
+	if h.Biome == nil {
+		return lines
+	}
+	b := h.Biome
+	lines[1] = b.Tile.Title
+	lines[2] = fmt.Sprintf("climax %v", b.GetClimaxNumber())
+	for i, animals := range [][]*Animal{b.Predator, b.Herbivore, b.Rooter} {
+		for _, a := range animals {
+			lines[3+i] += " " + renderAnimal(a)
+		}
+	}
+	return lines
+}
+
+// renderAnimal returns the token for an animal: the first letter of its player's color and its silhouette,
+// or i and its size for an immigrant.
+func renderAnimal(a *Animal) string {
+	if a.ImmigrantTile != nil || a.Dentition < 2 || a.Dentition > 5 {
+		return fmt.Sprintf("i%v", a.Size)
+	}
+	return fmt.Sprintf("%c%v", PlayerColors[a.Dentition-2][0], a.Silhouette)
+}
+
+// fitCell pads or truncates s to the width of a cell.
+func fitCell(s string) string {
+	if len(s) > renderCellWidth {
+		return s[:renderCellWidth]
+	}
+	return s + strings.Repeat(" ", renderCellWidth-len(s))
+}
@@ -0,0 +1,107 @@
+package megafauna
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// RuleSet is a complete set of rule data: the cards, tiles, inheritance tiles, latitudes and board layout that
+// a Game is set up from.  A RuleSet is never modified by the Games made from it, so one can be shared between Games.
+type RuleSet struct {
+	Cards            CardMap
+	Tiles            TileMap
+	InheritanceTiles InheritanceTileList
+	Latitudes        LatitudeMap
+	Board            BoardLayout
+}
+
+// ruleSources lists the sources that make up a RuleSet, in the order that Init parses them, along with the
+// embedded data that's used for any source that isn't supplied.
+var ruleSources = []struct {
+	name     string
+	embedded string
+}{
+	{MutationCardsFile, mutationCardSourceData},
+	{GenotypeCardsFile, genotypeCardSourceData},
+	{BiomeTilesFile, biomeTileSourceData},
+	{ImmigrantTilesFile, immigrantTileSourceData},
+	{InheritanceTilesFile, inheritanceTileSourceData},
+	{LatitudesFile, latitudeSourceData},
+	{BoardFile, boardSourceData},
+}
+
+// latitudeSourceData is the CSV data for the latitudes.
+const latitudeSourceData = `A,Arctic
+J,Jet Stream
+H,Horse Latitude
+T,Tropics
+O,Orogeny`
+
+// parseables returns the Parseable that parses each source of the RuleSet.
+func (rules *RuleSet) parseables() map[string]Parseable {
+	return map[string]Parseable{
+		MutationCardsFile:    rules.Cards.Source(MutationCardsFile),
+		GenotypeCardsFile:    rules.Cards.Source(GenotypeCardsFile),
+		BiomeTilesFile:       rules.Tiles.Source(BiomeTilesFile),
+		ImmigrantTilesFile:   rules.Tiles.Source(ImmigrantTilesFile),
+		InheritanceTilesFile: &rules.InheritanceTiles,
+		LatitudesFile:        rules.Latitudes,
+		BoardFile:            &rules.Board,
+	}
+}
+
+// Init parses a complete RuleSet in one call.  sources maps source names (MutationCardsFile,
+// BiomeTilesFile, etc.) to Readers of CSV data; the embedded data is used for any source that isn't in
+// sources, so Init(nil) returns the standard rules.
+func Init(sources map[string]io.Reader) (*RuleSet, error) {
+	rules := new(RuleSet)
+	rules.Cards = make(CardMap)
+	rules.Tiles = make(TileMap)
+	rules.InheritanceTiles = make(InheritanceTileList, 0)
+	rules.Latitudes = make(LatitudeMap)
+	rules.Board = make(BoardLayout, 0)
+
+	parseables := rules.parseables()
+	for name := range sources {
+		if parseables[name] == nil {
+			return nil, fmt.Errorf("Init: unknown rule source %v.", name)
+		}
+	}
+
+	for _, source := range ruleSources {
+		r := sources[source.name]
+		if r == nil {
+			r = strings.NewReader(source.embedded)
+		}
+		err := parseables[source.name].Parse(r)
+		if err != nil {
+			if _, ok := err.(*DataError); ok {
+				return nil, err
+			}
+			return nil, &DataError{File: source.name, Err: err}
+		}
+	}
+
+	// make sure that the board layout hangs together
+	_, err := rules.Board.NewBoard()
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// InitFS is like Init, but reads each source from the file of the same name in fsys, if there is one.
+func InitFS(fsys fs.FS) (*RuleSet, error) {
+	sources := make(map[string]io.Reader)
+	for _, source := range ruleSources {
+		r, err := openDataFile(fsys, source.name, source.embedded)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		sources[source.name] = r
+	}
+	return Init(sources)
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"megafauna"
+	"megafauna/store"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The types of Event that the server adds to the game's own events (see megafauna.GameEventType).
+const (
+	EventSnapshot    = "Snapshot"
+	EventActionTaken = "ActionTaken"
+)
+
+// maxHistory is the most events kept for clients that reconnect.  A client that has missed more than this
+// gets a new Snapshot.
+const maxHistory = 1000
+
+// streamTokenLifetime is how long a stream token works for.
+const streamTokenLifetime = time.Minute
+
+// subscriberBuffer is the number of batches of events that can be waiting for a client.  A client that falls
+// further behind than this is disconnected, and has to reconnect and resync.
+const subscriberBuffer = 16
+
+// Event describes one change to a game.  A Snapshot holds the whole game, and an ActionTaken holds the action
+// that a player took.  Every other Type is one of the megafauna.GameEventTypes, and the event holds the
+// GameEvent that the game told its observers about.  All of the events caused by one action have the same
+// Version, which is the version of the game after the action.
+type Event struct {
+	Version int
+	Type    string
+	Game    *gameResponse     `json:",omitempty"` // Snapshot
+	Action  *megafauna.Action `json:",omitempty"` // ActionTaken
+	*megafauna.GameEvent
+}
+
+// hostedGame is a Game being played on the server, with its version, its recent events, the clients
+// listening for them, the tokens of the seats that have been joined, and the stream tokens handed out for
+// them.  It observes the game, to collect the events caused by each action.  Its fields are protected by the
+// Server's mutex.
+type hostedGame struct {
+	id           string
+	game         *megafauna.Game
+	version      int
+	history      []*Event
+	pending      []*Event // the events caused by the action being applied
+	subscribers  map[chan []*Event]bool
+	tokens       store.Seats             // the hashes of the seat tokens, by dentition
+	streamTokens map[string]*streamToken // by the hash of the token
+}
+
+// streamToken is a short-lived token that opens one player's event stream.
+type streamToken struct {
+	dentition int
+	expires   time.Time
+}
+
+// newHostedGame hosts a game.  The game's version starts at the number of actions that have been applied to it,
+// so that it carries on from where it was if the server restarts.
+func newHostedGame(id string, g *megafauna.Game) *hostedGame {
+	hg := &hostedGame{
+		id:           id,
+		game:         g,
+		version:      len(g.Actions),
+		subscribers:  make(map[chan []*Event]bool),
+		tokens:       make(store.Seats),
+		streamTokens: make(map[string]*streamToken),
+	}
+	g.AddObserver(hg)
+	return hg
+}
+
+// Observe collects an event caused by the action being applied.
+func (hg *hostedGame) Observe(g *megafauna.Game, e *megafauna.GameEvent) {
+	hg.pending = append(hg.pending, &Event{Type: string(e.Type), GameEvent: e})
+}
+
+// view makes a snapshot of the game for the player with the given dentition.
+func (hg *hostedGame) view(viewer int) *gameResponse {
+	return &gameResponse{ID: hg.id, Version: hg.version, GameView: hg.game.ViewFor(viewer)}
+}
+
+// apply applies an action to the game, and publishes the events it caused.
+func (hg *hostedGame) apply(a *megafauna.Action) error {
+	hg.pending = []*Event{{Type: EventActionTaken, Action: a}}
+	err := hg.game.Apply(a)
+	events := hg.pending
+	hg.pending = nil
+	if err != nil {
+		return err
+	}
+	hg.version++
+	for _, e := range events {
+		e.Version = hg.version
+	}
+	hg.publish(events)
+	return nil
+}
+
+// publish adds events to the history and sends them to the subscribers.  The history is trimmed a whole
+// version at a time, so that it never starts partway through the events caused by one action.
+func (hg *hostedGame) publish(events []*Event) {
+	hg.history = append(hg.history, events...)
+	if len(hg.history) > maxHistory {
+		cut := len(hg.history) - maxHistory
+		for cut < len(hg.history) && hg.history[cut].Version == hg.history[cut-1].Version {
+			cut++
+		}
+		hg.history = hg.history[cut:]
+	}
+	for ch := range hg.subscribers {
+		select {
+		case ch <- events:
+		default:
+			hg.unsubscribe(ch)
+		}
+	}
+}
+
+// eventsSince returns the events after the given version.  It returns false if some of them are no longer
+// in the history, or if the version is one the game hasn't reached.
+func (hg *hostedGame) eventsSince(version int) ([]*Event, bool) {
+	if version < 0 || version > hg.version {
+		return nil, false
+	}
+	for i, e := range hg.history {
+		if e.Version > version {
+			if i == 0 && e.Version > version+1 {
+				return nil, false
+			}
+			return hg.history[i:], true
+		}
+	}
+	if version < hg.version {
+		// the history has been trimmed past everything the client needs
+		return nil, false
+	}
+	return nil, true
+}
+
+// subscribe returns a channel that gets every batch of events published from now on.
+func (hg *hostedGame) subscribe() chan []*Event {
+	ch := make(chan []*Event, subscriberBuffer)
+	hg.subscribers[ch] = true
+	return ch
+}
+
+// unsubscribe stops sending events to ch, and closes it.
+func (hg *hostedGame) unsubscribe(ch chan []*Event) {
+	if hg.subscribers[ch] {
+		delete(hg.subscribers, ch)
+		close(ch)
+	}
+}
+
+// newStreamToken returns a new stream token for a player, and when it expires.  Tokens that have already
+// expired are forgotten.
+func (hg *hostedGame) newStreamToken(dentition int, now time.Time) (string, time.Time) {
+	for hash, st := range hg.streamTokens {
+		if !now.Before(st.expires) {
+			delete(hg.streamTokens, hash)
+		}
+	}
+	token := newToken()
+	st := &streamToken{dentition: dentition, expires: now.Add(streamTokenLifetime)}
+	hg.streamTokens[hashToken(token)] = st
+	return token, st.expires
+}
+
+// authorizeStream checks that a stream token is one that opens the event stream of the player with the
+// given dentition, and that it hasn't expired.
+func (hg *hostedGame) authorizeStream(dentition int, token string, now time.Time) error {
+	st := hg.streamTokens[hashToken(token)]
+	if st == nil || st.dentition != dentition || !now.Before(st.expires) {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// streamEvents sends the game's events to the client as server-sent events.  The optional "dentition" query
+// parameter says whose view of the game a Snapshot should be; it needs a stream token for that player, in
+// the "token" query parameter.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, ErrStreamingUnsupported)
+		return
+	}
+	viewer := 0
+	if d := r.URL.Query().Get("dentition"); d != "" {
+		var err error
+		if viewer, err = strconv.Atoi(d); err != nil {
+			writeError(w, ErrPlayerNotFound)
+			return
+		}
+	}
+	since := r.Header.Get("Last-Event-ID")
+	if since == "" {
+		since = r.URL.Query().Get("since")
+	}
+
+	s.mu.Lock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		s.mu.Unlock()
+		writeError(w, err)
+		return
+	}
+	if viewer != 0 {
+		if err := hg.authorizeStream(viewer, r.URL.Query().Get("token"), time.Now()); err != nil {
+			s.mu.Unlock()
+			writeError(w, err)
+			return
+		}
+	}
+	var backlog []*Event
+	ok = false
+	if version, err := strconv.Atoi(since); err == nil {
+		backlog, ok = hg.eventsSince(version)
+	}
+	if !ok {
+		backlog = []*Event{{Version: hg.version, Type: EventSnapshot, Game: hg.view(viewer)}}
+	}
+	ch := hg.subscribe()
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		hg.unsubscribe(ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if writeEvents(w, backlog) != nil {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case events, ok := <-ch:
+			if !ok {
+				return
+			}
+			if writeEvents(w, events) != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvents writes events in the server-sent events format.  Each event's ID is its version.
+func writeEvents(w http.ResponseWriter, events []*Event) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Version, e.Type, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,451 @@
+// Package server hosts megafauna games over HTTP, with a JSON API:
+//
+//	POST /games                                  create a game; the body is {"Players": ["name", ...]}
+//	GET  /games/{id}                             the game, as seen by an observer
+//	POST /games/{id}/players/{dentition}/join    take a player's seat, and get its token
+//	GET  /games/{id}/players/{dentition}         the game, as seen by one player
+//	GET  /games/{id}/players/{dentition}/actions the actions that player can take right now
+//	POST /games/{id}/players/{dentition}/stream  get a short-lived token for that player's event stream
+//	POST /games/{id}/actions                     take an action; the body is a megafauna.Action
+//	GET  /games/{id}/events                      a stream of server-sent events describing changes to the game
+//
+// Each seat can be joined once, and the join returns a token for it.  Seeing the game as a player, getting
+// their actions, and taking an action for them all need that player's token, sent as an "Authorization:
+// Bearer <token>" header.  Seat tokens are never accepted in URLs, where they'd end up in logs and browser
+// histories.  Instead, a client that can't set headers on the event stream (such as EventSource) gets a
+// stream token, which only opens that player's event stream and expires after a minute, and sends it as a
+// "token" query parameter.  The server only keeps the hashes of seat tokens, and saves them with the game, so
+// players keep their seats when the server restarts.  Stream tokens are only kept in memory.
+//
+// Every change to a game increases its version.  The event stream starts with a Snapshot event holding the
+// whole game; a client that reconnects with a Last-Event-ID header (or a "since" query parameter) gets the
+// events it missed instead, if the server still has them.
+//
+// Errors are returned as {"Error": "message"} with an appropriate status code.
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"megafauna"
+	"megafauna/store"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrGameNotFound     = errors.New("Game not found.")
+	ErrPlayerNotFound   = errors.New("Player not found.")
+	ErrInvalidRequest   = errors.New("Invalid request.")
+	ErrNotFound         = errors.New("Not found.")
+	ErrMethodNotAllowed = errors.New("Method not allowed.")
+	ErrSeatTaken        = errors.New("That seat has already been taken.")
+	ErrInvalidToken     = errors.New("Invalid seat token.")
+
+	ErrStreamingUnsupported = errors.New("Streaming is not supported.")
+)
+
+// Server holds the games being played, in memory, and saves them to a Store if it has one.  It's an
+// http.Handler.
+type Server struct {
+	mu    sync.Mutex
+	games map[string]*hostedGame
+	store store.Store
+}
+
+// New creates a Server with no games, which doesn't save them.
+func New() *Server {
+	return &Server{games: make(map[string]*hostedGame)}
+}
+
+// NewWithStore creates a Server that saves its games to a Store, starting with the games already saved there.
+func NewWithStore(st store.Store) (*Server, error) {
+	s := New()
+	s.store = st
+	ids, err := st.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		g, seats, err := st.Load(id)
+		if err != nil {
+			return nil, fmt.Errorf("loading game %v: %w", id, err)
+		}
+		hg := newHostedGame(id, g)
+		for dentition, hash := range seats {
+			hg.tokens[dentition] = hash
+		}
+		s.games[id] = hg
+	}
+	return s, nil
+}
+
+// save saves a game to the server's store, if it has one.  The caller must hold s.mu.
+func (s *Server) save(hg *hostedGame) error {
+	if s.store == nil {
+		return nil
+	}
+	return s.store.Save(hg.id, hg.game, hg.tokens)
+}
+
+// saveWithAction saves a game to the server's store, if it has one, as it will be once an action is
+// applied.  The action is applied to a copy of the game, so if it's illegal or the game can't be saved, the
+// game itself is unchanged.  The caller must hold s.mu.
+func (s *Server) saveWithAction(hg *hostedGame, a *megafauna.Action) error {
+	if s.store == nil {
+		return nil
+	}
+	next, err := hg.game.Clone()
+	if err != nil {
+		return err
+	}
+	if err := next.Apply(a); err != nil {
+		return err
+	}
+	return s.store.Save(hg.id, next, hg.tokens)
+}
+
+// route is one of the API's endpoints.  In its pattern, "{id}" and "{dentition}" match any path segment.
+type route struct {
+	method  string
+	pattern []string
+	handler func(*Server, http.ResponseWriter, *http.Request, map[string]string)
+}
+
+var routes = []route{
+	{"POST", []string{"games"}, (*Server).createGame},
+	{"GET", []string{"games", "{id}"}, (*Server).getGame},
+	{"POST", []string{"games", "{id}", "players", "{dentition}", "join"}, (*Server).joinGame},
+	{"GET", []string{"games", "{id}", "players", "{dentition}"}, (*Server).getPlayerView},
+	{"GET", []string{"games", "{id}", "players", "{dentition}", "actions"}, (*Server).getLegalActions},
+	{"POST", []string{"games", "{id}", "players", "{dentition}", "stream"}, (*Server).createStreamToken},
+	{"POST", []string{"games", "{id}", "actions"}, (*Server).applyAction},
+	{"GET", []string{"games", "{id}", "events"}, (*Server).streamEvents},
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	found := false
+	for _, rt := range routes {
+		params, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		found = true
+		if rt.method == r.Method {
+			rt.handler(s, w, r, params)
+			return
+		}
+	}
+	if found {
+		writeError(w, ErrMethodNotAllowed)
+		return
+	}
+	writeError(w, ErrNotFound)
+}
+
+// match tells you whether the path segments match the route's pattern, and returns the values of the
+// pattern's parameters.
+func (rt route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.pattern) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, p := range rt.pattern {
+		if strings.HasPrefix(p, "{") {
+			params[strings.Trim(p, "{}")] = segments[i]
+		} else if p != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// gameResponse is a player's view of a game, with the game's ID and version.
+type gameResponse struct {
+	ID      string
+	Version int // increases every time the game changes
+	*megafauna.GameView
+}
+
+// createGameRequest is the body of a request to create a game.
+type createGameRequest struct {
+	Players []string
+}
+
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var req createGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrInvalidRequest)
+		return
+	}
+	g, err := megafauna.NewGame(req.Players)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	hg := newHostedGame(newID(), g)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.save(hg); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.games[hg.id] = hg
+	writeJSON(w, http.StatusCreated, hg.view(0))
+}
+
+func (s *Server) getGame(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.view(0))
+}
+
+// joinResponse is the body of the response to joining a game.
+type joinResponse struct {
+	Dentition int
+	Token     string // sent with requests that act for the player
+}
+
+func (s *Server) joinGame(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	p, err := lookupPlayer(params, hg.game)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if hg.tokens[p.Dentition] != "" {
+		writeError(w, ErrSeatTaken)
+		return
+	}
+	token := newToken()
+	hg.tokens[p.Dentition] = hashToken(token)
+	if err := s.save(hg); err != nil {
+		delete(hg.tokens, p.Dentition)
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, &joinResponse{Dentition: p.Dentition, Token: token})
+}
+
+// streamTokenResponse is the body of the response to asking for a stream token.
+type streamTokenResponse struct {
+	Token   string    // sent as the "token" query parameter of the event stream
+	Expires time.Time // when the token stops working
+}
+
+func (s *Server) createStreamToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	p, err := lookupPlayer(params, hg.game)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := hg.authorize(p.Dentition, r); err != nil {
+		writeError(w, err)
+		return
+	}
+	token, expires := hg.newStreamToken(p.Dentition, time.Now())
+	writeJSON(w, http.StatusCreated, &streamTokenResponse{Token: token, Expires: expires})
+}
+
+func (s *Server) getPlayerView(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	p, err := lookupPlayer(params, hg.game)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := hg.authorize(p.Dentition, r); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.view(p.Dentition))
+}
+
+func (s *Server) getLegalActions(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	p, err := lookupPlayer(params, hg.game)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := hg.authorize(p.Dentition, r); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.game.LegalActions(p.Dentition))
+}
+
+func (s *Server) applyAction(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var a megafauna.Action
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		writeError(w, ErrInvalidRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hg, err := s.lookupGame(params)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if hg.game.GetPlayer(a.Dentition) == nil {
+		writeError(w, ErrPlayerNotFound)
+		return
+	}
+	if err := hg.authorize(a.Dentition, r); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.saveWithAction(hg, &a); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := hg.apply(&a); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hg.view(a.Dentition))
+}
+
+// lookupGame finds the game whose ID is in the path parameters.  The caller must hold s.mu.
+func (s *Server) lookupGame(params map[string]string) (*hostedGame, error) {
+	hg, ok := s.games[params["id"]]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	return hg, nil
+}
+
+// lookupPlayer finds the player in g whose dentition is in the path parameters.
+func lookupPlayer(params map[string]string, g *megafauna.Game) (*megafauna.Player, error) {
+	dentition, err := strconv.Atoi(params["dentition"])
+	if err != nil {
+		return nil, ErrPlayerNotFound
+	}
+	p := g.GetPlayer(dentition)
+	if p == nil {
+		return nil, ErrPlayerNotFound
+	}
+	return p, nil
+}
+
+// authorize checks that the request's Authorization header carries the seat token for the player with the
+// given dentition.
+func (hg *hostedGame) authorize(dentition int, r *http.Request) error {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ErrInvalidToken
+	}
+	expected := hg.tokens[dentition]
+	if expected == "" || subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(expected)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// newID returns a random game ID.
+func newID() string {
+	return randomHex(8)
+}
+
+// newToken returns a random seat token.
+func newToken() string {
+	return randomHex(16)
+}
+
+// hashToken returns the SHA-256 hash of a token, in hex.  Only the hashes of tokens are kept, so the tokens
+// can't be read from where the games are saved, and looking one up takes the same time whatever it is.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes, in hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// statusCode returns the HTTP status for an error.
+func statusCode(err error) int {
+	switch err {
+	case megafauna.ErrInvalidPlayers, ErrInvalidRequest:
+		return http.StatusBadRequest
+	case megafauna.ErrCardNotFound, ErrGameNotFound, ErrPlayerNotFound, ErrNotFound:
+		return http.StatusNotFound
+	case ErrMethodNotAllowed:
+		return http.StatusMethodNotAllowed
+	case ErrInvalidToken:
+		return http.StatusForbidden
+	case megafauna.ErrNotYourTurn, megafauna.ErrGameOver, ErrSeatTaken:
+		return http.StatusConflict
+	case megafauna.ErrIllegalAction:
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+// errorResponse is the body of an error response.
+type errorResponse struct {
+	Error string
+}
+
+// writeError writes err as a JSON error response.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, statusCode(err), &errorResponse{Error: err.Error()})
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Print(err)
+	}
+}
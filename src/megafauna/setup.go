@@ -0,0 +1,102 @@
+package megafauna
+
+import (
+	"errors"
+)
+
+var ErrTileNotFound = errors.New("Tile not found.")
+
+// Setup records everything about a new Game that was decided at random: where the players sat, and the order
+// of the deck and of the card and tile stacks.  Once the game has started, nothing else is random, so a Setup
+// and the game's Actions are enough to recreate the game exactly.  All of its fields are keys, so it can be
+// encoded as JSON.
+type Setup struct {
+	Seats                []Seat
+	CardKeys             []string
+	TriassicCardKeys     []string
+	JurassicCardKeys     []string
+	CretaceousCardKeys   []string
+	TertiaryCardKeys     []string
+	UpperDisplayCardKeys []string
+	LowerDisplayCardKeys []string
+	MesozoicTileKeys     []string
+	CenozoicTileKeys     []string
+}
+
+// recordSetup saves the game's seats and stacks in g.Setup, and starts the action log.
+func (g *Game) recordSetup() {
+	s := new(Setup)
+	for _, p := range g.Players {
+		s.Seats = append(s.Seats, Seat{Name: p.Name, Dentition: p.Dentition})
+	}
+	s.CardKeys = copyKeys(g.CardKeys)
+	s.TriassicCardKeys = copyKeys(g.TriassicCardKeys)
+	s.JurassicCardKeys = copyKeys(g.JurassicCardKeys)
+	s.CretaceousCardKeys = copyKeys(g.CretaceousCardKeys)
+	s.TertiaryCardKeys = copyKeys(g.TertiaryCardKeys)
+	s.UpperDisplayCardKeys = copyKeys(g.UpperDisplayCardKeys)
+	s.LowerDisplayCardKeys = copyKeys(g.LowerDisplayCardKeys)
+	s.MesozoicTileKeys = copyKeys(g.MesozoicTileKeys)
+	s.CenozoicTileKeys = copyKeys(g.CenozoicTileKeys)
+	g.Setup = s
+	g.Actions = make([]*Action, 0)
+}
+
+// NewGameFromSetup recreates a new Game, as it was before anyone moved, from a RuleSet and a Setup.  Apply
+// the game's Actions to it to bring it up to date (see Replay).
+func NewGameFromSetup(rules *RuleSet, setup *Setup) (*Game, error) {
+	g, err := NewGameWithSeats(rules, setup.Seats)
+	if err != nil {
+		return nil, err
+	}
+	cardStacks := []*[]string{&g.CardKeys, &g.TriassicCardKeys, &g.JurassicCardKeys, &g.CretaceousCardKeys,
+		&g.TertiaryCardKeys, &g.UpperDisplayCardKeys, &g.LowerDisplayCardKeys}
+	setupCardStacks := [][]string{setup.CardKeys, setup.TriassicCardKeys, setup.JurassicCardKeys, setup.CretaceousCardKeys,
+		setup.TertiaryCardKeys, setup.UpperDisplayCardKeys, setup.LowerDisplayCardKeys}
+	for i, keys := range setupCardStacks {
+		for _, key := range keys {
+			if g.Cards[key] == nil {
+				return nil, ErrCardNotFound
+			}
+		}
+		*cardStacks[i] = copyKeys(keys)
+	}
+	for _, keys := range [][]string{setup.MesozoicTileKeys, setup.CenozoicTileKeys} {
+		for _, key := range keys {
+			if g.Tiles[key] == nil {
+				return nil, ErrTileNotFound
+			}
+		}
+	}
+	g.MesozoicTileKeys = copyKeys(setup.MesozoicTileKeys)
+	g.CenozoicTileKeys = copyKeys(setup.CenozoicTileKeys)
+	g.LowerDisplayGenes = make([]int, len(g.LowerDisplayCardKeys))
+	g.recordSetup()
+	return g, nil
+}
+
+// Replay recreates a Game from a RuleSet, its Setup, and the Actions that have been applied to it.
+func Replay(rules *RuleSet, setup *Setup, actions []*Action) (*Game, error) {
+	g, err := NewGameFromSetup(rules, setup)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range actions {
+		err = g.Apply(a)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// copyKeys returns a copy of a slice of keys.
+func copyKeys(keys []string) []string {
+	return append(make([]string, 0, len(keys)), keys...)
+}
+
+// Clone returns a copy of the game that can be played on without changing the original.  It's made by
+// replaying the game's Actions from its Setup, so it shares nothing with the original but the rules.
+func (g *Game) Clone() (*Game, error) {
+	return Replay(g.Rules, g.Setup, g.Actions)
+}
@@ -0,0 +1,154 @@
+package megafauna
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Sheets are US letter pages, drawn at the same 100 units to the inch as cards, with a half-inch margin that
+// printers can't print in.  Everything on a sheet stays inside the margin: a sheet holds 3 by 2 cards, or 3
+// by 5 tiles, which are two inches square.
+const (
+	svgSheetWidth  = 850
+	svgSheetHeight = 1100
+	svgSheetMargin = 50
+	svgTileSize    = 200
+
+	svgCardColumns = (svgSheetWidth - 2*svgSheetMargin) / svgCardWidth
+	svgCardRows    = (svgSheetHeight - 2*svgSheetMargin) / svgCardHeight
+	svgTileColumns = (svgSheetWidth - 2*svgSheetMargin) / svgTileSize
+	svgTileRows    = (svgSheetHeight - 2*svgSheetMargin) / svgTileSize
+)
+
+// The number of cards and tiles that fit on a sheet.
+const (
+	CardsPerSheet = svgCardColumns * svgCardRows
+	TilesPerSheet = svgTileColumns * svgTileRows
+)
+
+var ErrSheetFull = errors.New("Too many components for one sheet.")
+
+// RenderTileSVG draws the face of a tile as an SVG image, two inches square.  A biome tile shows its titles,
+// climax number, requirements, rooter requirements, niche and stars; an immigrant tile shows its titles, DNA,
+// and size, or that it's a predator.  Both show their latitude and whether they're land or sea.
+func RenderTileSVG(t *Tile, w io.Writer) error {
+	s := &svgWriter{w: w}
+	s.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="2in" height="2in" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		svgTileSize, svgTileSize)
+	writeTileSVG(s, t, 0, 0)
+	s.printf("</svg>\n")
+	return s.err
+}
+
+// writeTileSVG draws the face of a tile with its top left corner at x, y.
+func writeTileSVG(s *svgWriter, t *Tile, x, y int) {
+	s.printf(`<g transform="translate(%d,%d)">`+"\n", x, y)
+	fill := "#e0e0e0"
+	if t.IsBiomeTile() {
+		fill = biomeFill(t)
+	}
+	s.printf(`<rect x="1" y="1" width="%d" height="%d" rx="8" fill="%v" stroke="#333" stroke-width="2"/>`+"\n",
+		svgTileSize-2, svgTileSize-2, fill)
+
+	era := "Cenozoic"
+	if t.IsMesozoic {
+		era = "Mesozoic"
+	}
+	terrain := make([]string, 0)
+	if t.IsLand {
+		terrain = append(terrain, "land")
+	}
+	if t.IsSea {
+		terrain = append(terrain, "sea")
+	}
+	s.text(10, 18, 10, "start", "normal", fmt.Sprintf("%v %v", era, t.LatitudeKey))
+	s.text(svgTileSize-10, 18, 8, "end", "normal", t.Key)
+	s.text(svgTileSize/2, 40, 10, "middle", "normal", t.Supertitle)
+	writeWrappedSVG(s, svgTileSize/2, 58, 14, 22, t.Title, 2)
+
+	switch {
+	case t.BiomeData != nil:
+		b := t.BiomeData
+		s.text(svgTileSize/2, 110, 26, "middle", "bold", fmt.Sprint(b.ClimaxNumber))
+		lines := []string{"needs " + dnaOrNone(b.Requirements)}
+		if b.RooterRequirements != nil {
+			lines = append(lines, "rooters need "+b.RooterRequirements.Spec)
+		}
+		if b.Niche != nil {
+			lines = append(lines, "niche "+b.Niche.String())
+		}
+		for i, line := range lines {
+			s.text(svgTileSize/2, 132+i*14, 10, "middle", "normal", line)
+		}
+		if b.RedStar {
+			writeStarSVG(s, 20, 100, "#d62828")
+		}
+		if b.BlueStar {
+			writeStarSVG(s, svgTileSize-20, 100, "#1d3557")
+		}
+		if b.IsWarming {
+			s.text(svgTileSize/2, 186, 10, "middle", "bold", "warming")
+		}
+		if b.IsCooling {
+			s.text(svgTileSize/2, 186, 10, "middle", "bold", "cooling")
+		}
+		if b.IsOrogeny {
+			s.printf(`<polygon points="10,190 20,172 30,190" fill="#7f5539"/>` + "\n")
+		}
+	case t.ImmigrantData != nil:
+		i := t.ImmigrantData
+		s.text(svgTileSize/2, 116, 22, "middle", "bold", dnaOrNone(i.DNA))
+		if i.IsHerbivore {
+			s.text(svgTileSize/2, 140, 12, "middle", "normal", fmt.Sprintf("herbivore, size %v", i.Size))
+		} else {
+			s.text(svgTileSize/2, 140, 12, "middle", "normal", "1-tooth predator")
+		}
+	}
+	s.text(svgTileSize-10, svgTileSize-10, 9, "end", "normal", strings.Join(terrain, " and "))
+	s.printf("</g>\n")
+}
+
+// dnaOrNone returns a DNA spec, or "nothing" if it's empty.
+func dnaOrNone(d *DNASpec) string {
+	if d == nil || d.Spec == "" {
+		return "nothing"
+	}
+	return d.Spec
+}
+
+// RenderCardSheetSVG draws up to CardsPerSheet cards on a printable letter-size sheet, at their real size.
+func RenderCardSheetSVG(cards []*Card, w io.Writer) error {
+	if len(cards) > CardsPerSheet {
+		return ErrSheetFull
+	}
+	return renderSheetSVG(w, len(cards), svgCardColumns, svgCardRows, svgCardWidth, svgCardHeight, func(s *svgWriter, i, x, y int) {
+		writeCardSVG(s, cards[i], x, y)
+	})
+}
+
+// RenderTileSheetSVG draws up to TilesPerSheet tiles on a printable letter-size sheet, at their real size.
+func RenderTileSheetSVG(tiles []*Tile, w io.Writer) error {
+	if len(tiles) > TilesPerSheet {
+		return ErrSheetFull
+	}
+	return renderSheetSVG(w, len(tiles), svgTileColumns, svgTileRows, svgTileSize, svgTileSize, func(s *svgWriter, i, x, y int) {
+		writeTileSVG(s, tiles[i], x, y)
+	})
+}
+
+// renderSheetSVG draws a sheet of count components, each width by height, in a grid of columns by rows
+// that's centered on the page.  draw draws the i'th component at x, y.
+func renderSheetSVG(w io.Writer, count, columns, rows, width, height int, draw func(s *svgWriter, i, x, y int)) error {
+	s := &svgWriter{w: w}
+	s.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="8.5in" height="11in" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		svgSheetWidth, svgSheetHeight)
+	left := (svgSheetWidth - columns*width) / 2
+	top := (svgSheetHeight - rows*height) / 2
+	for i := 0; i < count; i++ {
+		draw(s, i, left+(i%columns)*width, top+(i/columns)*height)
+	}
+	s.printf("</svg>\n")
+	return s.err
+}
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"megafauna"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fileExtension is the extension of the files that a FileStore keeps games in.
+const fileExtension = ".json"
+
+// FileStore is a Store that keeps each game in a JSON file in a directory.
+type FileStore struct {
+	Dir   string
+	Rules *megafauna.RuleSet // the rules that loaded games are played with
+}
+
+// NewFileStore creates a FileStore in a directory, creating the directory if it doesn't exist.
+func NewFileStore(dir string, rules *megafauna.RuleSet) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{Dir: dir, Rules: rules}, nil
+}
+
+// fileRecord is the contents of a game's file.
+type fileRecord struct {
+	Setup   json.RawMessage
+	Actions json.RawMessage
+	Seats   json.RawMessage
+}
+
+// path returns the path of the file for a game.
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.Dir, id+fileExtension)
+}
+
+// Save implements Store.  The game is written to a temporary file, which then replaces the old one, so that a
+// crash can't leave a game half-written.
+func (s *FileStore) Save(id string, g *megafauna.Game, seats Seats) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	setup, actions, err := encode(g)
+	if err != nil {
+		return err
+	}
+	encodedSeats, err := encodeSeats(seats)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(&fileRecord{Setup: setup, Actions: actions, Seats: encodedSeats}, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.Dir, id+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.path(id))
+}
+
+// Load implements Store.
+func (s *FileStore) Load(id string) (*megafauna.Game, Seats, error) {
+	if err := checkID(id); err != nil {
+		return nil, nil, err
+	}
+	b, err := os.ReadFile(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var r fileRecord
+	err = json.Unmarshal(b, &r)
+	if err != nil {
+		return nil, nil, err
+	}
+	seats, err := decodeSeats(r.Seats)
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := decode(s.Rules, r.Setup, r.Actions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, seats, nil
+}
+
+// List implements Store.
+func (s *FileStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0)
+	for _, e := range entries {
+		id := strings.TrimSuffix(e.Name(), fileExtension)
+		if e.Type().IsRegular() && strings.HasSuffix(e.Name(), fileExtension) && checkID(id) == nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	err := os.Remove(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrGameNotFound
+	}
+	return err
+}
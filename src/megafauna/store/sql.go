@@ -0,0 +1,125 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"megafauna"
+)
+
+// SQLStore is a Store that keeps games in a SQL database, in a table with a row for each game.  It sticks to
+// SQL that SQLite understands, with ? placeholders.  The caller opens the database with whatever driver it
+// likes.
+type SQLStore struct {
+	DB    *sql.DB
+	Table string
+	Rules *megafauna.RuleSet // the rules that loaded games are played with
+}
+
+// NewSQLStore creates a SQLStore that uses the given table, creating the table if it doesn't exist.
+func NewSQLStore(db *sql.DB, table string, rules *megafauna.RuleSet) (*SQLStore, error) {
+	if err := checkID(table); err != nil {
+		return nil, err
+	}
+	s := &SQLStore{DB: db, Table: table, Rules: rules}
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS ` + table + ` (
+		id TEXT PRIMARY KEY,
+		setup TEXT NOT NULL,
+		actions TEXT NOT NULL,
+		seats TEXT NOT NULL
+	)`)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save implements Store.
+func (s *SQLStore) Save(id string, g *megafauna.Game, seats Seats) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	setup, actions, err := encode(g)
+	if err != nil {
+		return err
+	}
+	encodedSeats, err := encodeSeats(seats)
+	if err != nil {
+		return err
+	}
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`DELETE FROM `+s.Table+` WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO `+s.Table+` (id, setup, actions, seats) VALUES (?, ?, ?, ?)`,
+		id, string(setup), string(actions), string(encodedSeats))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Load implements Store.
+func (s *SQLStore) Load(id string) (*megafauna.Game, Seats, error) {
+	if err := checkID(id); err != nil {
+		return nil, nil, err
+	}
+	var setup, actions, encodedSeats string
+	err := s.DB.QueryRow(`SELECT setup, actions, seats FROM `+s.Table+` WHERE id = ?`, id).Scan(&setup, &actions, &encodedSeats)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrGameNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	seats, err := decodeSeats([]byte(encodedSeats))
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := decode(s.Rules, []byte(setup), []byte(actions))
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, seats, nil
+}
+
+// List implements Store.
+func (s *SQLStore) List() ([]string, error) {
+	rows, err := s.DB.Query(`SELECT id FROM ` + s.Table + ` ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Delete implements Store.
+func (s *SQLStore) Delete(id string) error {
+	if err := checkID(id); err != nil {
+		return err
+	}
+	result, err := s.DB.Exec(`DELETE FROM `+s.Table+` WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrGameNotFound
+	}
+	return nil
+}
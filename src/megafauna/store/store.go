@@ -0,0 +1,102 @@
+// Package store saves megafauna games so that they survive restarts.  A game is stored as its Setup and its
+// action log, along with its Seats, and is loaded by replaying the actions.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"megafauna"
+	"regexp"
+)
+
+var (
+	ErrGameNotFound = errors.New("Game not found.")
+	ErrInvalidID    = errors.New("Invalid game ID.")
+	ErrNoSetup      = errors.New("The game has no setup.")
+)
+
+// Seats are the hashes of the tokens of the seats that have been joined in a game, by dentition.  They're
+// stored with the game, so that players keep their seats when a server restarts.
+type Seats map[int]string
+
+// Store saves and loads games by ID.
+type Store interface {
+	// Save saves a game and its seats, replacing any game with the same ID.
+	Save(id string, g *megafauna.Game, seats Seats) error
+	// Load loads a game and its seats, returning ErrGameNotFound if there isn't one with that ID.
+	Load(id string) (*megafauna.Game, Seats, error)
+	// List returns the IDs of all of the saved games, in order.
+	List() ([]string, error)
+	// Delete deletes a game, returning ErrGameNotFound if there isn't one with that ID.
+	Delete(id string) error
+}
+
+// validID matches the IDs that a Store will accept.  They have to be safe to use as file names.
+var validID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// checkID returns ErrInvalidID if id can't be used as a game ID.
+func checkID(id string) error {
+	if !validID.MatchString(id) {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// record is what's stored for a game.
+type record struct {
+	Setup   *megafauna.Setup
+	Actions []*megafauna.Action
+}
+
+// encode returns the encoded setup and action log of a game.
+func encode(g *megafauna.Game) (setup []byte, actions []byte, err error) {
+	if g.Setup == nil {
+		return nil, nil, ErrNoSetup
+	}
+	setup, err = json.Marshal(g.Setup)
+	if err != nil {
+		return nil, nil, err
+	}
+	actions, err = json.Marshal(g.Actions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return setup, actions, nil
+}
+
+// encodeSeats returns a game's encoded seats.
+func encodeSeats(seats Seats) ([]byte, error) {
+	if seats == nil {
+		seats = make(Seats)
+	}
+	return json.Marshal(seats)
+}
+
+// decodeSeats decodes a game's seats.  A game saved without any has none.
+func decodeSeats(data []byte) (Seats, error) {
+	seats := make(Seats)
+	if len(data) == 0 {
+		return seats, nil
+	}
+	if err := json.Unmarshal(data, &seats); err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+// decode recreates a game from its encoded setup and action log.
+func decode(rules *megafauna.RuleSet, setup []byte, actions []byte) (*megafauna.Game, error) {
+	var r record
+	err := json.Unmarshal(setup, &r.Setup)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(actions, &r.Actions)
+	if err != nil {
+		return nil, err
+	}
+	if r.Setup == nil {
+		return nil, ErrNoSetup
+	}
+	return megafauna.Replay(rules, r.Setup, r.Actions)
+}
@@ -0,0 +1,70 @@
+package megafauna
+
+import (
+	"sort"
+)
+
+// SuitableBiome is a biome that a species could live in, as found by Board.SuitableBiomes.
+type SuitableBiome struct {
+	Biome *Biome
+	Steps int       // the number of migration steps from the nearest of the starting habitats
+	Prey  []*Animal // for a predator, the herbivores and rooters in the biome that it could feed on
+}
+
+// SuitableBiomes finds the biomes that a species with the given genome and size could live in, in the given
+// role (SlotHerbivore, SlotRooter or SlotPredator), within the given number of migration steps of the
+// habitats with the given keys, as the species would migrate (see MigrationReach).  If no keys are given,
+// every biome on the board is in reach, at 0 steps.  If a key isn't on the board, ErrHabitatNotFound is
+// returned.
+//
+// A herbivore must be able to feed on a biome's Requirements, and a rooter on its RooterRequirements.  A
+// predator needs prey: a herbivore or rooter in the biome that it can catch (see CanPreyOn) and could feed on
+// in a cull, as a new species that has no animals of its own on the board.  Sea biomes are only open to
+// marine species.  The biomes are returned nearest first, and in the order of Habitats at the same distance.
+func (b *Board) SuitableBiomes(genome *DNASpec, size int, role string, steps int, fromKeys ...string) ([]*SuitableBiome, error) {
+	distances := make(map[*Habitat]int)
+	if len(fromKeys) == 0 {
+		for _, biome := range b.Biomes() {
+			distances[biome.Habitat] = 0
+		}
+	} else {
+		var err error
+		distances, _, err = b.migrate(genome, steps, fromKeys)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := make([]*SuitableBiome, 0)
+	for _, biome := range b.Biomes() {
+		step, ok := distances[biome.Habitat]
+		if !ok || (biome.IsSeaOnly() && genome.GetDNAValue("M") == 0) {
+			continue
+		}
+		data := biome.Tile.BiomeData
+		switch role {
+		case SlotHerbivore:
+			if genome.CanFeedOn(data.Requirements) {
+				result = append(result, &SuitableBiome{Biome: biome, Steps: step})
+			}
+		case SlotRooter:
+			if data.RooterRequirements != nil && genome.CanFeedOn(data.RooterRequirements) {
+				result = append(result, &SuitableBiome{Biome: biome, Steps: step})
+			}
+		case SlotPredator:
+			predator := &Animal{Size: size, Genome: genome}
+			prey := make([]*Animal, 0)
+			for _, a := range append(copyAnimals(biome.Herbivore), biome.Rooter...) {
+				if predator.canFeedOn(a) && genome.CanPreyOn(a.Genome) {
+					prey = append(prey, a)
+				}
+			}
+			if len(prey) > 0 {
+				result = append(result, &SuitableBiome{Biome: biome, Steps: step, Prey: prey})
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Steps < result[j].Steps
+	})
+	return result, nil
+}
@@ -0,0 +1,278 @@
+package megafauna
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Sizes, in SVG user units, of the things drawn by RenderSVG and RenderCardSVG.  Cards are drawn at 100 units
+// to the inch, so a card is the size of a poker card.
+const (
+	svgHabitatWidth  = 180
+	svgHabitatHeight = 140
+	svgTokenWidth    = 40
+	svgCardWidth     = 250
+	svgCardHeight    = 350
+)
+
+// svgPlayerColors are the fills used for the PlayerColors.
+var svgPlayerColors = map[string]string{
+	"Red":    "#c0392b",
+	"Orange": "#e67e22",
+	"Green":  "#27ae60",
+	"White":  "#f4f4f4",
+}
+
+// svgWriter writes SVG elements, remembering the first error so that callers only need to check once.
+type svgWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *svgWriter) printf(format string, args ...interface{}) {
+	if s.err == nil {
+		_, s.err = fmt.Fprintf(s.w, format, args...)
+	}
+}
+
+// text writes a text element.  The text is escaped; anchor is "start", "middle" or "end".
+func (s *svgWriter) text(x, y int, size int, anchor string, weight string, text string) {
+	s.printf(`<text x="%d" y="%d" font-size="%d" text-anchor="%v" font-weight="%v">%v</text>`+"\n",
+		x, y, size, anchor, weight, html.EscapeString(text))
+}
+
+// RenderSVG draws the board of a game as an SVG image.  The habitats are laid out the way their adjacent
+// habitats say they are, as with Board.Render.  Each habitat shows its key and printed climax number (with a
+// triangle for orogeny habitats); a biome shows its title, climax number, and red or blue star; and the animals
+// in each slot are tokens in their player's color, labelled with their silhouette.  Immigrants are grey.
+func RenderSVG(g *Game, w io.Writer) error {
+	grid, columns := g.Board.grid()
+	width, height := columns*svgHabitatWidth, len(grid)*svgHabitatHeight
+	s := &svgWriter{w: w}
+	s.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		width, height, width, height)
+	for row, habitats := range grid {
+		for col, h := range habitats {
+			if h != nil {
+				writeHabitatSVG(s, g, h, col*svgHabitatWidth, row*svgHabitatHeight)
+			}
+		}
+	}
+	s.printf("</svg>\n")
+	return s.err
+}
+
+// writeHabitatSVG draws a habitat with its top left corner at x, y.
+func writeHabitatSVG(s *svgWriter, g *Game, h *Habitat, x, y int) {
+	s.printf(`<g transform="translate(%d,%d)">`+"\n", x, y)
+	fill := "#e8e0cc"
+	if h.Biome != nil {
+		fill = biomeFill(h.Biome.Tile)
+	}
+	s.printf(`<rect x="1" y="1" width="%d" height="%d" fill="%v" stroke="#333" stroke-width="2"/>`+"\n",
+		svgHabitatWidth-2, svgHabitatHeight-2, fill)
+	s.text(6, 16, 12, "start", "bold", fmt.Sprintf("%v  %v", h.Key, h.ClimaxNumber))
+	if h.IsOrogeny {
+		s.printf(`<polygon points="%d,16 %d,4 %d,16" fill="#7f5539"/>`+"\n", svgHabitatWidth-26, svgHabitatWidth-19, svgHabitatWidth-12)
+	}
+
+	if b := h.Biome; b != nil {
+		s.text(6, 32, 11, "start", "normal", b.Tile.Title)
+		s.text(6, 46, 10, "start", "normal", fmt.Sprintf("climax %v", b.GetClimaxNumber()))
+		if b.Tile.BiomeData.RedStar {
+			writeStarSVG(s, svgHabitatWidth-20, 42, "#d62828")
+		}
+		if b.Tile.BiomeData.BlueStar {
+			writeStarSVG(s, svgHabitatWidth-40, 42, "#1d3557")
+		}
+		for i, slot := range []struct {
+			label   string
+			animals []*Animal
+		}{{"P", b.Predator}, {"H", b.Herbivore}, {"R", b.Rooter}} {
+			ty := 58 + i*26
+			s.text(6, ty+15, 10, "start", "bold", slot.label)
+			for j, a := range slot.animals {
+				writeTokenSVG(s, g, a, 20+j*(svgTokenWidth+2), ty)
+			}
+		}
+	}
+	s.printf("</g>\n")
+}
+
+// biomeFill returns the color to fill a biome with: blue for sea, green for land, and in between for both.
+func biomeFill(t *Tile) string {
+	switch {
+	case t.IsSea && t.IsLand:
+		return "#b7d7c9"
+	case t.IsSea:
+		return "#a8c8e8"
+	}
+	return "#c8e0a8"
+}
+
+// writeStarSVG draws a five-pointed star centered at x, y.
+func writeStarSVG(s *svgWriter, x, y int, color string) {
+	s.printf(`<polygon points="%d,%d %d,%d %d,%d %d,%d %d,%d %d,%d %d,%d %d,%d %d,%d %d,%d" fill="%v"/>`+"\n",
+		x, y-8, x+2, y-3, x+8, y-3, x+3, y+1, x+5, y+7, x, y+4, x-5, y+7, x-3, y+1, x-8, y-3, x-2, y-3, color)
+}
+
+// writeTokenSVG draws an animal token with its top left corner at x, y.  A player's animal is labelled with
+// its silhouette, and an immigrant with its size.
+func writeTokenSVG(s *svgWriter, g *Game, a *Animal, x, y int) {
+	fill, label := "#999", "imm"
+	if a.ImmigrantTile == nil {
+		if p := g.GetPlayer(a.Dentition); p != nil {
+			fill = svgPlayerColors[p.Color]
+			label = silhouetteName(p.IsDinosaur, a.Silhouette)
+		}
+	} else if a.Size > 0 {
+		label = fmt.Sprintf("imm %v", a.Size)
+	}
+	s.printf(`<rect x="%d" y="%d" width="%d" height="22" rx="6" fill="%v" stroke="#222"/>`+"\n", x, y, svgTokenWidth, fill)
+	s.text(x+svgTokenWidth/2, y+15, 9, "middle", "normal", label)
+}
+
+// silhouetteName returns the name of a silhouette, e.g. "dino" or "cat".
+func silhouetteName(isDinosaur bool, silhouette int) string {
+	names := MammalSilhouettes
+	if isDinosaur {
+		names = DinosaurSilhouettes
+	}
+	if silhouette < 0 || silhouette >= len(names) {
+		return "?"
+	}
+	return names[silhouette]
+}
+
+// RenderCardSVG draws the face of a card as an SVG image, at poker card size.  A mutation card shows its
+// titles, DNA, size range and instinct; a genotype card shows its mammal half above its dinosaur half; and
+// both have the card's event in a banner along the bottom.
+func RenderCardSVG(c *Card, w io.Writer) error {
+	s := &svgWriter{w: w}
+	s.printf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n",
+		svgCardWidth, svgCardHeight, svgCardWidth, svgCardHeight)
+	writeCardSVG(s, c, 0, 0)
+	s.printf("</svg>\n")
+	return s.err
+}
+
+// writeCardSVG draws the face of a card with its top left corner at x, y.
+func writeCardSVG(s *svgWriter, c *Card, x, y int) {
+	s.printf(`<g transform="translate(%d,%d)">`+"\n", x, y)
+	s.printf(`<rect x="1" y="1" width="%d" height="%d" rx="12" fill="#fffdf5" stroke="#333" stroke-width="2"/>`+"\n",
+		svgCardWidth-2, svgCardHeight-2)
+	switch {
+	case c.Mutation != nil:
+		m := c.Mutation
+		s.text(svgCardWidth/2, 28, 11, "middle", "normal", m.Supertitle)
+		s.text(svgCardWidth/2, 52, 18, "middle", "bold", m.Title)
+		writeWrappedSVG(s, svgCardWidth/2, 74, 10, 40, m.Subtitle, 5)
+		s.text(svgCardWidth/2, 160, 28, "middle", "bold", m.Mutation.Spec)
+		s.text(svgCardWidth/2, 186, 12, "middle", "normal", fmt.Sprintf("size %v-%v", m.MinSize, m.MaxSize))
+		if m.InstinctKey != "" {
+			s.text(svgCardWidth/2, 206, 12, "middle", "normal", "instinct "+m.InstinctKey)
+		}
+		writeWrappedSVG(s, svgCardWidth/2, 226, 10, 40, m.Reminder, 3)
+	case c.Genotype != nil:
+		writeGenotypeSVG(s, c.Genotype.MammalData, false, 12)
+		s.printf(`<line x1="12" y1="140" x2="%d" y2="140" stroke="#999"/>`+"\n", svgCardWidth-12)
+		writeGenotypeSVG(s, c.Genotype.DinosaurData, true, 140)
+	}
+	s.text(svgCardWidth-10, 16, 8, "end", "normal", c.Key)
+	if c.Event != nil {
+		writeEventSVG(s, c.Event, svgCardHeight-84)
+	}
+	s.printf("</g>\n")
+}
+
+// writeGenotypeSVG draws one half of a genotype card, starting at y.
+func writeGenotypeSVG(s *svgWriter, d *GenotypeCardData, isDinosaur bool, y int) {
+	if d == nil {
+		return
+	}
+	side := "Mammal"
+	if isDinosaur {
+		side = "Dinosaur"
+	}
+	s.text(svgCardWidth/2, y+18, 10, "middle", "normal", fmt.Sprintf("%v: %v", side, d.Family))
+	s.text(svgCardWidth/2, y+40, 16, "middle", "bold", d.Title)
+	s.text(svgCardWidth/2, y+58, 10, "middle", "normal", d.Subtitle)
+	s.text(svgCardWidth/2, y+86, 22, "middle", "bold", d.DNASpec.Spec)
+	s.text(svgCardWidth/2, y+108, 11, "middle", "normal",
+		fmt.Sprintf("%v, size %v-%v", silhouetteName(isDinosaur, d.SilhouetteIndex), d.MinSize, d.MaxSize))
+}
+
+// writeEventSVG draws an event banner across a card, starting at y.
+func writeEventSVG(s *svgWriter, e *Event, y int) {
+	fill := "#ddd"
+	switch {
+	case e.IsCatastrophe:
+		fill = "#f4a261"
+	case e.IsWarming:
+		fill = "#f6bd60"
+	case e.IsCooling:
+		fill = "#a8dadc"
+	}
+	s.printf(`<rect x="8" y="%d" width="%d" height="70" rx="6" fill="%v" stroke="#555"/>`+"\n", y, svgCardWidth-16, fill)
+	s.text(svgCardWidth/2, y+18, 11, "middle", "bold", strings.Join(eventTags(e), " · "))
+	writeWrappedSVG(s, svgCardWidth/2, y+34, 10, 40, e.Description, 3)
+}
+
+// eventTags returns short descriptions of what an event does, for its banner.
+func eventTags(e *Event) []string {
+	tags := make([]string, 0)
+	if e.IsDrawTwo {
+		tags = append(tags, "draw two")
+	}
+	if e.IsWarming {
+		tags = append(tags, "warming")
+	}
+	if e.IsCooling {
+		tags = append(tags, "cooling")
+	}
+	if e.IsCatastrophe {
+		tags = append(tags, fmt.Sprintf("catastrophe %v", e.CatastropheLevel))
+	}
+	if e.IsMilankovich {
+		tags = append(tags, "Milankovich "+strings.Join(e.MilankovichLatitudeKeys, ""))
+	}
+	if len(tags) == 0 {
+		tags = append(tags, "event")
+	}
+	return tags
+}
+
+// writeWrappedSVG writes text centered at x, wrapped to lines of at most width characters, starting at y.  At
+// most maxLines lines are written; if there's more, the last one ends with an ellipsis.
+func writeWrappedSVG(s *svgWriter, x, y, size, width int, text string, maxLines int) {
+	lines := wrapText(text, width)
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] += "…"
+	}
+	for i, line := range lines {
+		s.text(x, y+i*(size+3), size, "middle", "normal", line)
+	}
+}
+
+// wrapText breaks text into lines of at most width characters, at spaces where it can.
+func wrapText(text string, width int) []string {
+	lines := make([]string, 0)
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
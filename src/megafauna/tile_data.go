@@ -1,10 +1,10 @@
 package megafauna
 
 import (
-	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"strconv"
 	"strings"
 )
@@ -45,184 +45,285 @@ var (
 	errInvalidLatitudeKey = errors.New("LatitudeKey must be one of " + LatitudeKeys + ".")
 )
 
-// GetTiles builds the master map of all tiles.
+// GetTiles builds the master map of all tiles from the embedded tile data.
 func GetTiles() (map[string]*Tile, error) {
+	return GetTilesFrom(strings.NewReader(biomeTileSourceData), strings.NewReader(immigrantTileSourceData))
+}
+
+// GetTilesFrom builds the master map of all tiles from CSV data in the same format as the embedded data.
+func GetTilesFrom(biomes io.Reader, immigrants io.Reader) (map[string]*Tile, error) {
 	tiles := make(map[string]*Tile)
-	reader := strings.NewReader(biomeTileSourceData)
-	err := parsebiomeTiles(reader, tiles)
+	err := parsebiomeTiles(biomes, tiles, nil)
 	if err != nil {
 		return nil, err
 	}
-	reader = strings.NewReader(immigrantTileSourceData)
-	err = parseimmigrantTiles(reader, tiles)
+	err = parseimmigrantTiles(immigrants, tiles, nil)
 	if err != nil {
 		return nil, err
 	}
 	return tiles, nil
 }
 
-// parsebiomeTiles takes a Reader containing Tile data in CSV format, parses the data into Tiles, and populates
-// the (pre-made) map with the Tiles. 
-func parsebiomeTiles(r io.Reader, tiles map[string]*Tile) error {
-	csvReader := csv.NewReader(r)
-	records, err := csvReader.ReadAll()
+// GetTilesFS builds the master map of all tiles from the BiomeTilesFile and ImmigrantTilesFile in fsys.
+// If either file doesn't exist, the embedded data is used in its place.
+func GetTilesFS(fsys fs.FS) (map[string]*Tile, error) {
+	biomes, err := openDataFile(fsys, BiomeTilesFile, biomeTileSourceData)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer biomes.Close()
+	immigrants, err := openDataFile(fsys, ImmigrantTilesFile, immigrantTileSourceData)
+	if err != nil {
+		return nil, err
 	}
-	for _, record := range records {
-		t := new(Tile)
-		t.BiomeData = new(BiomeTileData)
-		b := t.BiomeData
+	defer immigrants.Close()
+	return GetTilesFrom(biomes, immigrants)
+}
+
+// Number of fields in each record of the tile CSV files.
+const (
+	biomeTileFieldCount       = biomeTileCoolingField + 1
+	immigrantTileFieldCount   = immigrantTileTitleField + 1
+	inheritanceTileFieldCount = 6
+)
 
-		t.Key = record[biomeTileKeyField]
-		t.IsMesozoic, err = strconv.ParseBool(record[biomeTileIsMesozoicField])
+// parsebiomeTiles takes a Reader containing Tile data in CSV format, parses the data into Tiles, and populates
+// the (pre-made) map with the Tiles.  If report is nil, it stops at the first problem; otherwise it adds every
+// problem to the report.
+func parsebiomeTiles(r io.Reader, tiles map[string]*Tile, report *ValidationReport) error {
+	return readRecords(r, BiomeTilesFile, biomeTileFieldCount, report, func(record []string) error {
+		t, err := parseBiomeTile(record)
 		if err != nil {
-			return nil
+			return err
 		}
+		return addTile(tiles, t)
+	})
+}
 
-		t.LatitudeKey = record[biomeTileLatitudeKeyField]
+// parseBiomeTile parses a single biome tile record.
+func parseBiomeTile(record []string) (*Tile, error) {
+	var err error
+	t := new(Tile)
+	t.BiomeData = new(BiomeTileData)
+	b := t.BiomeData
 
-		if len(t.LatitudeKey) != 1 || !strings.Contains(LatitudeKeys, t.LatitudeKey) {
-			return errInvalidLatitudeKey
-		}
+	t.Key = record[biomeTileKeyField]
+	if t.Key == "" {
+		return nil, fieldError("Key", ErrMissingKey)
+	}
+	t.IsMesozoic, err = strconv.ParseBool(record[biomeTileIsMesozoicField])
+	if err != nil {
+		return nil, fieldError("IsMesozoic", err)
+	}
 
-		b.ClimaxNumber, err = strconv.Atoi(record[biomeTileClimaxNumberField])
-		if err != nil {
-			return err
-		}
+	t.LatitudeKey = record[biomeTileLatitudeKeyField]
 
-		t.Supertitle = record[biomeTileSupertitleField]
-		t.Title = record[biomeTileTitleField]
-
-		switch record[biomeTileTypeField] {
-		case "L":
-			t.IsLand = true
-			if t.LatitudeKey == "O" {
-				b.IsOrogeny = true
-			}
-		case "S":
-			t.IsSea = true
-		case "B":
-			t.IsLand = true
-			t.IsSea = true
-		default:
-			return errInvalidType
-		}
+	if len(t.LatitudeKey) != 1 || !strings.Contains(LatitudeKeys, t.LatitudeKey) {
+		return nil, fieldError("LatitudeKey", errInvalidLatitudeKey)
+	}
 
-		b.Requirements = MakeDNASpec(record[biomeTileRequirementsField])
-		spec := record[biomeTileRooterRequirementsField]
-		if spec != "" {
-			b.RooterRequirements = MakeDNASpec(record[biomeTileRooterRequirementsField])
-		}
-		b.Niche, err = MakeNiche(record[biomeTileNicheField])
-		if err != nil {
-			return err
-		}
-		b.RedStar, err = strconv.ParseBool(record[biomeTileRedStarField])
-		if err != nil {
-			return nil
-		}
-		b.BlueStar, err = strconv.ParseBool(record[biomeTileBlueStarField])
-		if err != nil {
-			return err
+	b.ClimaxNumber, err = strconv.Atoi(record[biomeTileClimaxNumberField])
+	if err != nil {
+		return nil, fieldError("ClimaxNumber", err)
+	}
+
+	t.Supertitle = record[biomeTileSupertitleField]
+	t.Title = record[biomeTileTitleField]
+
+	switch record[biomeTileTypeField] {
+	case "L":
+		t.IsLand = true
+		if t.LatitudeKey == "O" {
+			b.IsOrogeny = true
 		}
-		b.IsWarming, err = strconv.ParseBool(record[biomeTileWarmingField])
-		if err != nil {
-			return err
+	case "S":
+		t.IsSea = true
+	case "B":
+		t.IsLand = true
+		t.IsSea = true
+	default:
+		return nil, fieldError("Type", errInvalidType)
+	}
+
+	b.Requirements = MakeDNASpec(record[biomeTileRequirementsField])
+	if b.Requirements == nil {
+		return nil, fieldError("Requirements", ErrInvalidDNASpec)
+	}
+	spec := record[biomeTileRooterRequirementsField]
+	if spec != "" {
+		b.RooterRequirements = MakeDNASpec(spec)
+		if b.RooterRequirements == nil {
+			return nil, fieldError("RooterRequirements", ErrInvalidDNASpec)
 		}
-		b.IsCooling, err = strconv.ParseBool(record[biomeTileCoolingField])
+	}
+	b.Niche, err = MakeNiche(record[biomeTileNicheField])
+	if err != nil {
+		return nil, fieldError("Niche", err)
+	}
+	b.RedStar, err = strconv.ParseBool(record[biomeTileRedStarField])
+	if err != nil {
+		return nil, fieldError("RedStar", err)
+	}
+	b.BlueStar, err = strconv.ParseBool(record[biomeTileBlueStarField])
+	if err != nil {
+		return nil, fieldError("BlueStar", err)
+	}
+	b.IsWarming, err = strconv.ParseBool(record[biomeTileWarmingField])
+	if err != nil {
+		return nil, fieldError("IsWarming", err)
+	}
+	b.IsCooling, err = strconv.ParseBool(record[biomeTileCoolingField])
+	if err != nil {
+		return nil, fieldError("IsCooling", err)
+	}
+	return t, nil
+}
+
+// parseimmigrantTiles takes a Reader containing Tile data in CSV format, parses the data into Tiles, and populates
+// the (pre-made) map with the Tiles.  If report is nil, it stops at the first problem; otherwise it adds every
+// problem to the report.
+func parseimmigrantTiles(r io.Reader, tiles map[string]*Tile, report *ValidationReport) error {
+	return readRecords(r, ImmigrantTilesFile, immigrantTileFieldCount, report, func(record []string) error {
+		t, err := parseImmigrantTile(record)
 		if err != nil {
 			return err
 		}
-		tiles[t.Key] = t
-	}
-	return nil
+		return addTile(tiles, t)
+	})
 }
 
-// parseimmigrantTiles takes a Reader containing Tile data in CSV format, parses the data into Tiles, and populates
-// the (pre-made) map with the Tiles. 
-func parseimmigrantTiles(r io.Reader, tiles map[string]*Tile) error {
-	csvReader := csv.NewReader(r)
-	records, err := csvReader.ReadAll()
-	if err != nil {
-		return err
-	}
+// parseImmigrantTile parses a single immigrant tile record.
+func parseImmigrantTile(record []string) (*Tile, error) {
+	var err error
+	t := new(Tile)
+	t.ImmigrantData = new(ImmigrantTileData)
+	i := t.ImmigrantData
 
-	fmterr := func(index int, err error) error {
-		return fmt.Errorf("Line %v: %v", index+1, err.Error())
+	t.Key = record[immigrantTileKeyField]
+	if t.Key == "" {
+		return nil, fieldError("Key", ErrMissingKey)
+	}
+	t.IsMesozoic, err = strconv.ParseBool(record[immigrantTileIsMesozoicField])
+	if err != nil {
+		return nil, fieldError("IsMesozoic", err)
 	}
 
-	for index, record := range records {
-		t := new(Tile)
-		t.ImmigrantData = new(ImmigrantTileData)
-		i := t.ImmigrantData
-
-		t.Key = record[immigrantTileKeyField]
-		t.IsMesozoic, err = strconv.ParseBool(record[immigrantTileIsMesozoicField])
-		if err != nil {
-			return fmterr(index, err)
-		}
+	t.LatitudeKey = record[immigrantTileLatitudeKeyField]
 
-		t.LatitudeKey = record[immigrantTileLatitudeKeyField]
+	if len(t.LatitudeKey) != 1 || !strings.Contains(LatitudeKeys, t.LatitudeKey) {
+		return nil, fieldError("LatitudeKey", errInvalidLatitudeKey)
+	}
 
-		if len(t.LatitudeKey) != 1 || !strings.Contains(LatitudeKeys, t.LatitudeKey) {
-			return fmterr(index, errInvalidLatitudeKey)
-		}
+	t.Supertitle = record[immigrantTileSupertitleField]
+	t.Title = record[immigrantTileTitleField]
 
-		t.Supertitle = record[immigrantTileSupertitleField]
-		t.Title = record[immigrantTileTitleField]
+	t.IsLand, err = strconv.ParseBool(record[immigrantTileIsLandField])
+	if err != nil {
+		return nil, fieldError("IsLand", err)
+	}
+	t.IsSea, err = strconv.ParseBool(record[immigrantTileIsSeaField])
+	if err != nil {
+		return nil, fieldError("IsSea", err)
+	}
 
-		t.IsLand, err = strconv.ParseBool(record[immigrantTileIsLandField])
+	size := record[immigrantTileSizeField]
+	if size == "" {
+		i.IsHerbivore = false
+	} else {
+		i.IsHerbivore = true
+		i.Size, err = strconv.Atoi(size)
 		if err != nil {
-			return fmterr(index, err)
+			return nil, fieldError("Size", err)
 		}
-		t.IsSea, err = strconv.ParseBool(record[immigrantTileIsSeaField])
-		if err != nil {
-			return fmterr(index, err)
-		}
-
-		size := record[immigrantTileSizeField]
-		if size == "" {
-			i.IsHerbivore = false
-		} else {
-			i.IsHerbivore = true
-			i.Size, err = strconv.Atoi(size)
-			if err != nil {
-				return fmterr(index, err)
-			}
+		if i.Size < 1 {
+			return nil, fieldError("Size", ErrInvalidMinSize)
 		}
+	}
 
-		i.DNA = MakeDNASpec(record[immigrantTileDNAField])
+	i.DNA = MakeDNASpec(record[immigrantTileDNAField])
+	if i.DNA == nil {
+		return nil, fieldError("DNA", ErrInvalidDNASpec)
+	}
+	return t, nil
+}
 
-		tiles[t.Key] = t
+// addTile adds a Tile to the map, unless there's already a Tile with the same key.
+func addTile(tiles map[string]*Tile, t *Tile) error {
+	if tiles[t.Key] != nil {
+		return fieldError("Key", fmt.Errorf("%w: %v", ErrDuplicateKey, t.Key))
 	}
+	tiles[t.Key] = t
 	return nil
 }
 
-// GetInheritanceTiles parses the inheritance tile data and returns a slice containing the 5 inheritance tiles. 
+// GetInheritanceTiles parses the embedded inheritance tile data and returns a slice containing the 5 inheritance tiles. 
 func GetInheritanceTiles() []*InheritanceTile {
-	r := strings.NewReader(inheritanceTileSourceData)
-	csvReader := csv.NewReader(r)
-	records, _ := csvReader.ReadAll()
-	result := make([]*InheritanceTile, 0)
-	for _, record := range records {
-		t := new(InheritanceTile)
-		ob := new(InheritanceTileData)
-		re := new(InheritanceTileData)
-		t.Obverse = ob
-		t.Reverse = re
+	result, _ := GetInheritanceTilesFrom(strings.NewReader(inheritanceTileSourceData))
+	return result
+}
 
-		ob.MinSize, _ = strconv.Atoi(record[0])
-		ob.MaxSize, _ = strconv.Atoi(record[1])
-		ob.DNA = MakeDNASpec(record[2])
+// GetInheritanceTilesFS parses the InheritanceTilesFile in fsys, or the embedded data if there's no such file.
+func GetInheritanceTilesFS(fsys fs.FS) ([]*InheritanceTile, error) {
+	r, err := openDataFile(fsys, InheritanceTilesFile, inheritanceTileSourceData)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return GetInheritanceTilesFrom(r)
+}
 
-		re.MinSize, _ = strconv.Atoi(record[3])
-		re.MaxSize, _ = strconv.Atoi(record[4])
-		re.DNA = MakeDNASpec(record[5])
+// GetInheritanceTilesFrom parses inheritance tile data in CSV format from r.
+func GetInheritanceTilesFrom(r io.Reader) ([]*InheritanceTile, error) {
+	result := make([]*InheritanceTile, 0)
+	err := parseInheritanceTiles(r, &result, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseInheritanceTiles parses inheritance tile data in CSV format and appends the tiles to result.  If report
+// is nil, it stops at the first problem; otherwise it adds every problem to the report.
+func parseInheritanceTiles(r io.Reader, result *[]*InheritanceTile, report *ValidationReport) error {
+	return readRecords(r, InheritanceTilesFile, inheritanceTileFieldCount, report, func(record []string) error {
+		var err error
+		t := new(InheritanceTile)
+		t.Obverse, err = parseInheritanceTileData(record[0:3], "Obverse")
+		if err != nil {
+			return err
+		}
+		t.Reverse, err = parseInheritanceTileData(record[3:6], "Reverse")
+		if err != nil {
+			return err
+		}
+		*result = append(*result, t)
+		return nil
+	})
+}
 
-		result = append(result, t)
+// parseInheritanceTileData parses one side of an inheritance tile: its minimum size, maximum size and DNA.
+func parseInheritanceTileData(fields []string, side string) (*InheritanceTileData, error) {
+	var err error
+	d := new(InheritanceTileData)
+	d.MinSize, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fieldError(side+"MinSize", ErrInvalidMinSize)
 	}
-	return result
+	d.MaxSize, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fieldError(side+"MaxSize", ErrInvalidMaxSize)
+	}
+	err = checkSizes(d.MinSize, d.MaxSize)
+	if err != nil {
+		e := err.(*DataError)
+		e.Field = side + e.Field
+		return nil, e
+	}
+	d.DNA = MakeDNASpec(fields[2])
+	if d.DNA == nil {
+		return nil, fieldError(side+"DNA", ErrInvalidDNASpec)
+	}
+	return d, nil
 }
 
 const biomeTileSourceData = `MA16,TRUE,A,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,TRUE,FALSE,FALSE,FALSE
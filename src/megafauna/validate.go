@@ -0,0 +1,105 @@
+package megafauna
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// DataError describes a problem found in one of the card or tile data files.
+type DataError struct {
+	File  string // the name of the data file, e.g. MutationCardsFile
+	Line  int    // the line of the file the problem was found on; for JSON and YAML data, the entry number
+	Field string // the name of the field with the problem, if it's known
+	Err   error  // the problem itself
+}
+
+// fieldError makes a DataError for a problem with the named field.  The parsers fill in the file and line.
+func fieldError(field string, err error) *DataError {
+	return &DataError{Field: field, Err: err}
+}
+
+// Error formats a DataError for display, e.g. "biome_tiles.csv line 3 (RedStar): ..."
+func (e *DataError) Error() string {
+	location := make([]string, 0)
+	if e.File != "" {
+		location = append(location, e.File)
+	}
+	if e.Line > 0 {
+		location = append(location, fmt.Sprintf("line %v", e.Line))
+	}
+	if e.Field != "" {
+		location = append(location, fmt.Sprintf("(%v)", e.Field))
+	}
+	return fmt.Sprintf("%v: %v", strings.Join(location, " "), e.Err)
+}
+
+// Unwrap returns the underlying error, so that errors.Is can find e.g. ErrInvalidDNASpec.
+func (e *DataError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationReport is the list of every problem found by ValidateData.
+type ValidationReport []*DataError
+
+// add appends a problem to the report.
+func (r *ValidationReport) add(e *DataError) {
+	*r = append(*r, e)
+}
+
+// Error formats the report for display, one problem per line.
+func (r ValidationReport) Error() string {
+	lines := make([]string, len(r)+1)
+	lines[0] = fmt.Sprintf("%v problem(s) found in the data:", len(r))
+	for i, e := range r {
+		lines[i+1] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateData parses all of the embedded card and tile data, and returns a ValidationReport containing every
+// problem it finds, or nil if there are none.
+func ValidateData() error {
+	return ValidateDataFS(nil)
+}
+
+// ValidateDataFS is like ValidateData, but validates the data files in fsys.  As with GetCardsFS and
+// GetTilesFS, the embedded data is validated in place of any file that fsys doesn't contain.
+func ValidateDataFS(fsys fs.FS) error {
+	var report ValidationReport
+
+	cards := make(map[string]*Card)
+	tiles := make(map[string]*Tile)
+	inheritanceTiles := make([]*InheritanceTile, 0)
+
+	files := []struct {
+		name     string
+		embedded string
+		parse    func(r io.Reader) error
+	}{
+		{MutationCardsFile, mutationCardSourceData, func(r io.Reader) error { return parseMutationCards(r, cards, &report) }},
+		{GenotypeCardsFile, genotypeCardSourceData, func(r io.Reader) error { return parseGenotypeCards(r, cards, &report) }},
+		{BiomeTilesFile, biomeTileSourceData, func(r io.Reader) error { return parsebiomeTiles(r, tiles, &report) }},
+		{ImmigrantTilesFile, immigrantTileSourceData, func(r io.Reader) error { return parseimmigrantTiles(r, tiles, &report) }},
+		{InheritanceTilesFile, inheritanceTileSourceData, func(r io.Reader) error { return parseInheritanceTiles(r, &inheritanceTiles, &report) }},
+	}
+
+	for _, file := range files {
+		r, err := openDataFile(fsys, file.name, file.embedded)
+		if err != nil {
+			report.add(&DataError{File: file.name, Err: err})
+			continue
+		}
+		err = file.parse(r)
+		r.Close()
+		if err != nil {
+			report.add(&DataError{File: file.name, Err: err})
+		}
+	}
+
+	if len(report) == 0 {
+		return nil
+	}
+	return report
+}
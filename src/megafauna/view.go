@@ -0,0 +1,159 @@
+package megafauna
+
+// GameView is a snapshot of a Game as one player is allowed to see it: the board, the displays, the tarpit
+// and every player's species, but only the sizes of the deck and of the card and tile stacks, so that nobody
+// can see what's coming.  A Game is full of pointers that lead back to where they came from (tiles to players,
+// biomes to habitats, habitats to each other), so it can't be encoded as JSON directly; a GameView can.  It
+// shares nothing with the Game, so it can be kept after the game has moved on.
+type GameView struct {
+	Viewer       int // the dentition of the player the view is for, or 0 for an observer
+	Turn         int
+	Era          string
+	Climate      int
+	IsOver       bool
+	ActivePlayer int // the dentition of the player whose turn it is, or 0 if the game is over
+	Winner       int // the dentition of the winner, or 0 if the game isn't over
+	Players      []*PlayerView
+	UpperDisplay []string
+	LowerDisplay []*DisplayCardView
+	Biomes       []*BiomeView
+	Tarpit       []string
+	//
+	// the number of cards and tiles left in each stack
+	//
+	DeckCards       int
+	TriassicCards   int
+	JurassicCards   int
+	CretaceousCards int
+	TertiaryCards   int
+	MesozoicTiles   int
+	CenozoicTiles   int
+}
+
+// PlayerView is a snapshot of a Player.
+type PlayerView struct {
+	Name             string
+	Color            string
+	Dentition        int
+	IsDinosaur       bool
+	Genes            int
+	Score            int
+	CardKeys         []string
+	AnimalTokens     []int
+	InheritanceTiles int // the number of unused inheritance tiles
+	Species          []*SpeciesView
+}
+
+// SpeciesView is a snapshot of one of a player's species.
+type SpeciesView struct {
+	Silhouette int
+	DNA        string
+	Size       int
+}
+
+// DisplayCardView is a card in the lower display, and the genes on it.
+type DisplayCardView struct {
+	Key   string
+	Genes int
+}
+
+// BiomeView is a snapshot of a Biome and the animals in it.
+type BiomeView struct {
+	HabitatKey string
+	TileKey    string
+	Title      string
+	Predator   []*AnimalView
+	Herbivore  []*AnimalView
+	Rooter     []*AnimalView
+}
+
+// AnimalView is a snapshot of an Animal.
+type AnimalView struct {
+	Dentition    int
+	Silhouette   int
+	Size         int
+	DNA          string
+	ImmigrantKey string `json:",omitempty"`
+}
+
+// ViewFor makes a snapshot of the game for the player with the given dentition.  Use a dentition of 0 for
+// an observer; at the moment, players and observers see the same things.
+func (g *Game) ViewFor(dentition int) *GameView {
+	v := &GameView{
+		Viewer:          dentition,
+		Turn:            g.Turn,
+		Era:             g.Era(),
+		Climate:         g.Climate,
+		IsOver:          g.IsOver,
+		Players:         make([]*PlayerView, 0, len(g.Players)),
+		UpperDisplay:    append([]string{}, g.UpperDisplayCardKeys...),
+		LowerDisplay:    make([]*DisplayCardView, 0, len(g.LowerDisplayCardKeys)),
+		Biomes:          make([]*BiomeView, 0),
+		Tarpit:          append([]string{}, g.TarpitTileKeys...),
+		DeckCards:       len(g.CardKeys),
+		TriassicCards:   len(g.TriassicCardKeys),
+		JurassicCards:   len(g.JurassicCardKeys),
+		CretaceousCards: len(g.CretaceousCardKeys),
+		TertiaryCards:   len(g.TertiaryCardKeys),
+		MesozoicTiles:   len(g.MesozoicTileKeys),
+		CenozoicTiles:   len(g.CenozoicTileKeys),
+	}
+	if p := g.GetActivePlayer(); p != nil {
+		v.ActivePlayer = p.Dentition
+	}
+	if p := g.Winner(); p != nil {
+		v.Winner = p.Dentition
+	}
+	for _, p := range g.Players {
+		v.Players = append(v.Players, p.view())
+	}
+	for i, key := range g.LowerDisplayCardKeys {
+		v.LowerDisplay = append(v.LowerDisplay, &DisplayCardView{Key: key, Genes: g.LowerDisplayGenes[i]})
+	}
+	for _, b := range g.Board.Biomes() {
+		v.Biomes = append(v.Biomes, &BiomeView{
+			HabitatKey: b.Key,
+			TileKey:    b.Tile.Key,
+			Title:      b.Tile.Title,
+			Predator:   animalViews(b.Predator),
+			Herbivore:  animalViews(b.Herbivore),
+			Rooter:     animalViews(b.Rooter),
+		})
+	}
+	return v
+}
+
+// view makes a snapshot of p.
+func (p *Player) view() *PlayerView {
+	v := &PlayerView{
+		Name:             p.Name,
+		Color:            p.Color,
+		Dentition:        p.Dentition,
+		IsDinosaur:       p.IsDinosaur,
+		Genes:            p.Genes,
+		Score:            p.Score,
+		CardKeys:         append([]string{}, p.CardKeys...),
+		AnimalTokens:     append([]int{}, p.AnimalTokens...),
+		InheritanceTiles: len(p.InheritanceTiles),
+		Species:          make([]*SpeciesView, 0),
+	}
+	for s, genome := range p.Genomes {
+		if genome != nil {
+			v.Species = append(v.Species, &SpeciesView{Silhouette: s, DNA: genome.Spec, Size: p.SpeciesSizes[s]})
+		}
+	}
+	return v
+}
+
+// animalViews makes snapshots of a slice of animals.
+func animalViews(animals []*Animal) []*AnimalView {
+	views := make([]*AnimalView, 0, len(animals))
+	for _, a := range animals {
+		v := &AnimalView{Dentition: a.Dentition, Silhouette: a.Silhouette, Size: a.Size, DNA: a.Genome.Spec}
+		if a.ImmigrantTile != nil {
+			v.ImmigrantKey = a.ImmigrantTile.Key
+		}
+		views = append(views, v)
+	}
+	return views
+}
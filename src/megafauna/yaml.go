@@ -0,0 +1,491 @@
+package megafauna
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The YAML data files use the same schema as the JSON ones.  Rather than depend on a YAML library, this file
+// implements the small subset of YAML the data needs: block mappings and sequences, plain and quoted scalars,
+// flow sequences of scalars, and comments.  A YAML document is decoded into the same generic values that
+// encoding/json uses, and then converted to the doc types by way of JSON.  A plain (unquoted) scalar is only
+// read as a number or a bool where the doc type has one, so that "title: 1984" is still a string.
+
+// ErrInvalidYAML is wrapped by the errors returned for YAML that can't be parsed.
+var ErrInvalidYAML = errors.New("Invalid YAML.")
+
+// ParseCardsYAML parses a list of cards in YAML format into a map of Cards.
+func ParseCardsYAML(r io.Reader) (map[string]*Card, error) {
+	var docs []*cardDoc
+	err := decodeYAML(r, &docs)
+	if err != nil {
+		return nil, err
+	}
+	return cardsFromDocs(docs)
+}
+
+// ParseTilesYAML parses a list of biome and immigrant tiles in YAML format into a map of Tiles.
+func ParseTilesYAML(r io.Reader) (map[string]*Tile, error) {
+	var docs []*tileDoc
+	err := decodeYAML(r, &docs)
+	if err != nil {
+		return nil, err
+	}
+	return tilesFromDocs(docs)
+}
+
+// ParseInheritanceTilesYAML parses a list of inheritance tiles in YAML format.
+func ParseInheritanceTilesYAML(r io.Reader) ([]*InheritanceTile, error) {
+	var docs []*inheritanceTileDoc
+	err := decodeYAML(r, &docs)
+	if err != nil {
+		return nil, err
+	}
+	return inheritanceTilesFromDocs(docs)
+}
+
+// ParseLatitudesYAML parses a list of latitudes in YAML format into a LatitudeMap.
+func ParseLatitudesYAML(r io.Reader) (LatitudeMap, error) {
+	var docs []*latitudeDoc
+	err := decodeYAML(r, &docs)
+	if err != nil {
+		return nil, err
+	}
+	return latitudesFromDocs(docs)
+}
+
+// WriteCardsYAML writes the cards to w in YAML format, in key order.
+func WriteCardsYAML(w io.Writer, cards map[string]*Card) error {
+	return writeYAML(w, cardsToDocs(cards))
+}
+
+// WriteTilesYAML writes the biome and immigrant tiles to w in YAML format, in key order.
+func WriteTilesYAML(w io.Writer, tiles map[string]*Tile) error {
+	return writeYAML(w, tilesToDocs(tiles))
+}
+
+// WriteInheritanceTilesYAML writes the inheritance tiles to w in YAML format.
+func WriteInheritanceTilesYAML(w io.Writer, tiles []*InheritanceTile) error {
+	return writeYAML(w, inheritanceTilesToDocs(tiles))
+}
+
+// WriteLatitudesYAML writes the latitudes to w in YAML format, in key order.
+func WriteLatitudesYAML(w io.Writer, latitudes LatitudeMap) error {
+	return writeYAML(w, latitudesToDocs(latitudes))
+}
+
+// decodeYAML parses the YAML in r and stores the result in the value pointed to by v, as encoding/json would.
+func decodeYAML(r io.Reader, v interface{}) error {
+	lines, err := readYAMLLines(r)
+	if err != nil {
+		return err
+	}
+	p := &yamlParser{lines: lines}
+	var value interface{}
+	if len(lines) > 0 {
+		value, err = p.parseNode(lines[0].indent)
+		if err != nil {
+			return err
+		}
+		if p.pos < len(lines) {
+			return p.errorf("unexpected indentation")
+		}
+	}
+	data, err := json.Marshal(conformYAML(value, reflect.TypeOf(v)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// interfaceType is the type of an interface{}.
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// conformYAML resolves the plain scalars in a decoded YAML value to suit the type that it will be stored in:
+// a plain scalar becomes a number or a bool where t has one, and otherwise stays a string.  Where t says
+// nothing about a value, its plain scalars are resolved as YAML would.
+func conformYAML(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := value.(type) {
+	case yamlPlain:
+		return v.resolveAs(t.Kind())
+	case []interface{}:
+		elem := interfaceType
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			elem = t.Elem()
+		}
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = conformYAML(item, elem)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = conformYAML(item, yamlFieldType(t, key))
+		}
+		return result
+	}
+	return value
+}
+
+// yamlFieldType returns the type of the value stored under key in a mapping of type t, matching struct fields
+// by name the way encoding/json does.
+func yamlFieldType(t reflect.Type, key string) reflect.Type {
+	switch t.Kind() {
+	case reflect.Map:
+		return t.Elem()
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if name, _ := yamlFieldName(t.Field(i)); strings.EqualFold(name, key) {
+				return t.Field(i).Type
+			}
+		}
+	}
+	return interfaceType
+}
+
+// yamlPlain is a plain (unquoted) scalar, which might be a string, a number, a bool or null.
+type yamlPlain string
+
+// resolve returns the value of a plain scalar as YAML would read it: null, a bool, a number, or a string.
+func (p yamlPlain) resolve() interface{} {
+	text := string(p)
+	switch text {
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	case "null", "Null", "NULL", "~":
+		return nil
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		return n
+	}
+	if text != "" && strings.ContainsRune("+-.0123456789", rune(text[0])) {
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	}
+	return text
+}
+
+// resolveAs returns the value of a plain scalar that will be stored in a value of the given kind.  It's
+// always a string for a string, unless it's null; for anything else, it's read as YAML would.
+func (p yamlPlain) resolveAs(kind reflect.Kind) interface{} {
+	value := p.resolve()
+	if kind == reflect.String && value != nil {
+		return string(p)
+	}
+	return value
+}
+
+// yamlLine is a line of YAML with its indentation measured and any comment removed.
+type yamlLine struct {
+	number  int
+	indent  int
+	content string
+}
+
+// readYAMLLines reads the meaningful lines of a YAML document.
+func readYAMLLines(r io.Reader) ([]*yamlLine, error) {
+	lines := make([]*yamlLine, 0)
+	scanner := bufio.NewScanner(r)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := scanner.Text()
+		if strings.Contains(text, "\t") {
+			return nil, fmt.Errorf("%w line %v: tabs aren't allowed", ErrInvalidYAML, number)
+		}
+		content := strings.TrimRight(stripYAMLComment(text), " ")
+		trimmed := strings.TrimLeft(content, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		lines = append(lines, &yamlLine{number, len(content) - len(trimmed), trimmed})
+	}
+	return lines, scanner.Err()
+}
+
+// stripYAMLComment removes a trailing comment from a line, taking care not to look inside quotes.
+func stripYAMLComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || text[i-1] == ' '):
+			return text[:i]
+		}
+	}
+	return text
+}
+
+// yamlParser parses yamlLines into generic values.
+type yamlParser struct {
+	lines []*yamlLine
+	pos   int
+}
+
+// errorf makes an error for the current line.
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	number := 0
+	if p.pos < len(p.lines) {
+		number = p.lines[p.pos].number
+	} else if len(p.lines) > 0 {
+		number = p.lines[len(p.lines)-1].number
+	}
+	return fmt.Errorf("%w line %v: %v", ErrInvalidYAML, number, fmt.Sprintf(format, args...))
+}
+
+// isSequenceItem tells you whether a line's content is a block sequence item.
+func isSequenceItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// parseNode parses the block sequence or mapping that starts at the current line, which is at indent.
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	if isSequenceItem(p.lines[p.pos].content) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+// parseSequence parses a block sequence whose items are at indent.
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	result := make([]interface{}, 0)
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent || !isSequenceItem(line.content) {
+			return nil, p.errorf("expected a sequence item")
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(line.content, "-"), " ")
+		var item interface{}
+		var err error
+		switch {
+		case rest == "":
+			// the item is a node on the following lines
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				item, err = p.parseNode(p.lines[p.pos].indent)
+			}
+		case isSequenceItem(rest) || isMappingEntry(rest):
+			// the item is a node that starts on this line; treat its first line as if it were on its own
+			line.indent += len(line.content) - len(rest)
+			line.content = rest
+			item, err = p.parseNode(line.indent)
+		default:
+			item, err = parseYAMLScalar(rest)
+			if err != nil {
+				err = p.errorf("%v", err)
+			}
+			p.pos++
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// isMappingEntry tells you whether content looks like "key: value" or "key:".
+func isMappingEntry(content string) bool {
+	if strings.ContainsAny(content[:1], "\"'[{") {
+		return false
+	}
+	return strings.HasSuffix(content, ":") || strings.Contains(content, ": ")
+}
+
+// parseMapping parses a block mapping whose keys are at indent.
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	result := make(map[string]interface{})
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent || !isMappingEntry(line.content) {
+			return nil, p.errorf("expected a mapping entry")
+		}
+		var key, rest string
+		if i := strings.Index(line.content, ": "); i >= 0 {
+			key, rest = line.content[:i], strings.TrimLeft(line.content[i+2:], " ")
+		} else {
+			key = strings.TrimSuffix(line.content, ":")
+		}
+		if _, ok := result[key]; ok {
+			return nil, p.errorf("duplicate key %v", key)
+		}
+		p.pos++
+
+		if rest != "" {
+			value, err := parseYAMLScalar(rest)
+			if err != nil {
+				p.pos--
+				return nil, p.errorf("%v", err)
+			}
+			result[key] = value
+			continue
+		}
+
+		// the value is a node on the following lines, which may be a sequence at the same indentation
+		result[key] = nil
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || (next.indent == indent && isSequenceItem(next.content)) {
+				value, err := p.parseNode(next.indent)
+				if err != nil {
+					return nil, err
+				}
+				result[key] = value
+			}
+		}
+	}
+	return result, nil
+}
+
+// parseYAMLScalar parses a quoted or plain scalar, or a flow sequence of scalars.  A plain scalar is returned
+// as a yamlPlain, since what it means depends on where it's stored.
+func parseYAMLScalar(text string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("flow mappings aren't supported")
+	case strings.HasPrefix(text, "\""):
+		return strconv.Unquote(text)
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return nil, fmt.Errorf("unterminated string %v", text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated flow sequence %v", text)
+		}
+		result := make([]interface{}, 0)
+		inner := strings.TrimSpace(text[1 : len(text)-1])
+		if inner == "" {
+			return result, nil
+		}
+		for _, item := range strings.Split(inner, ",") {
+			value, err := parseYAMLScalar(strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	}
+	return yamlPlain(text), nil
+}
+
+// writeYAML writes v to w in YAML.  v must be made of structs (or pointers to them) with json tags, slices,
+// strings, ints and bools, which is all the doc types use.
+func writeYAML(w io.Writer, v interface{}) error {
+	buf := bufio.NewWriter(w)
+	writeYAMLValue(buf, reflect.ValueOf(v), 0)
+	return buf.Flush()
+}
+
+// writeYAMLValue writes a block sequence or mapping at indent.
+func writeYAMLValue(w *bufio.Writer, v reflect.Value, indent int) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	prefix := strings.Repeat(" ", indent)
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+				item = item.Elem()
+			}
+			if item.Kind() == reflect.Struct {
+				// write the first field on the same line as the dash
+				var entry strings.Builder
+				entryWriter := bufio.NewWriter(&entry)
+				writeYAMLValue(entryWriter, item, indent+2)
+				entryWriter.Flush()
+				fmt.Fprintf(w, "%v- %v", prefix, strings.TrimLeft(entry.String(), " "))
+				continue
+			}
+			fmt.Fprintf(w, "%v- %v\n", prefix, formatYAMLScalar(item))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name, omitEmpty := yamlFieldName(t.Field(i))
+			field := v.Field(i)
+			if omitEmpty && field.IsZero() {
+				continue
+			}
+			for field.Kind() == reflect.Ptr && !field.IsNil() {
+				field = field.Elem()
+			}
+			switch {
+			case field.Kind() == reflect.Ptr:
+				fmt.Fprintf(w, "%v%v: null\n", prefix, name)
+			case field.Kind() == reflect.Struct:
+				fmt.Fprintf(w, "%v%v:\n", prefix, name)
+				writeYAMLValue(w, field, indent+2)
+			case field.Kind() == reflect.Slice && field.Len() == 0:
+				fmt.Fprintf(w, "%v%v: []\n", prefix, name)
+			case field.Kind() == reflect.Slice:
+				fmt.Fprintf(w, "%v%v:\n", prefix, name)
+				writeYAMLValue(w, field, indent+2)
+			default:
+				fmt.Fprintf(w, "%v%v: %v\n", prefix, name, formatYAMLScalar(field))
+			}
+		}
+	}
+}
+
+// yamlFieldName returns the name from a struct field's json tag, and whether it's omitempty.
+func yamlFieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return f.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	return parts[0], len(parts) > 1 && parts[1] == "omitempty"
+}
+
+// formatYAMLScalar formats a string, int or bool, quoting strings that would otherwise be misread.
+func formatYAMLScalar(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		value, err := parseYAMLScalar(s)
+		if plain, ok := value.(yamlPlain); ok {
+			value = plain.resolve()
+		}
+		if s == "" || err != nil || value != s || s != strings.TrimSpace(s) ||
+			strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") || strings.Contains(s, ": ") ||
+			strings.Contains(s, " #") || isMappingEntry(s) {
+			return strconv.Quote(s)
+		}
+		return s
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,132 @@
+package megafauna_test
+
+import (
+	"math/rand"
+	"megafauna"
+	"testing"
+)
+
+func TestLegalActions_NotYourTurn(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := g.GetActivePlayer()
+	for _, p := range g.Players {
+		actions := g.LegalActions(p.Dentition)
+		if p == active && len(actions) == 0 {
+			t.Error("The active player has no legal actions.")
+		}
+		if p != active && len(actions) != 0 {
+			t.Errorf("%v isn't active, but has %v legal actions.", p, len(actions))
+		}
+	}
+	for _, p := range g.Players {
+		if p == active {
+			continue
+		}
+		err = g.Apply(&megafauna.Action{Type: megafauna.ActionPass, Dentition: p.Dentition, Silhouette: -1})
+		if err != megafauna.ErrNotYourTurn {
+			t.Errorf("Expected ErrNotYourTurn, got %v", err)
+		}
+	}
+}
+
+func TestApply_Pass(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := g.GetActivePlayer()
+	genes := p.Genes
+	err = g.Apply(&megafauna.Action{Type: megafauna.ActionPass, Dentition: p.Dentition, Silhouette: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Genes != genes+1 {
+		t.Errorf("Expected %v genes after passing, got %v", genes+1, p.Genes)
+	}
+	if g.GetActivePlayer() == p || g.Turn != 1 {
+		t.Error("Passing didn't end the player's turn.")
+	}
+}
+
+func TestApply_Illegal(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := g.GetActivePlayer()
+	err = g.Apply(&megafauna.Action{Type: megafauna.ActionBuyCard, Dentition: p.Dentition, CardKey: "no such card"})
+	if err != megafauna.ErrCardNotFound {
+		t.Errorf("Expected ErrCardNotFound, got %v", err)
+	}
+	err = g.Apply(&megafauna.Action{Type: megafauna.ActionPopulate, Dentition: p.Dentition, Silhouette: 3, HabitatKey: "T", Slot: megafauna.SlotHerbivore})
+	if err != megafauna.ErrIllegalAction {
+		t.Errorf("Expected ErrIllegalAction, got %v", err)
+	}
+}
+
+// TestApply_PlayOut plays random legal actions until the game ends, checking that every player's animals are
+// accounted for along the way.
+func TestApply_PlayOut(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C", "D"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(1))
+	for turn := 0; !g.IsOver; turn++ {
+		if turn > 10000 {
+			t.Fatal("The game didn't end.")
+		}
+		p := g.GetActivePlayer()
+		actions := g.LegalActions(p.Dentition)
+		if err := g.Apply(actions[r.Intn(len(actions))]); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range g.Players {
+			for s, animals := range p.Species {
+				if len(animals)+p.AnimalTokens[s] != 8 {
+					t.Fatalf("%v has lost track of animals of species %v.", p, s)
+				}
+			}
+		}
+	}
+	if g.Winner() == nil {
+		t.Error("The game is over, but there's no winner.")
+	}
+	if len(g.LegalActions(g.Players[0].Dentition)) != 0 {
+		t.Error("There are legal actions after the game is over.")
+	}
+}
+
+// TestApply_TertiaryTiles checks that cards drawn in the Tertiary still place tiles when there aren't any
+// Cenozoic tiles.
+func TestApply_TertiaryTiles(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.TriassicCardKeys = nil
+	g.JurassicCardKeys = nil
+	g.CretaceousCardKeys = nil
+	g.CenozoicTileKeys = nil
+	if g.Era() != megafauna.EraTertiary {
+		t.Fatalf("Expected the %v, got %v", megafauna.EraTertiary, g.Era())
+	}
+	tiles := len(g.MesozoicTileKeys)
+	p := g.GetActivePlayer()
+	for _, a := range g.LegalActions(p.Dentition) {
+		if a.Type != megafauna.ActionBuyCard {
+			continue
+		}
+		if err := g.Apply(a); err != nil {
+			t.Fatal(err)
+		}
+		if len(g.MesozoicTileKeys) >= tiles {
+			t.Errorf("Expected the Tertiary card to place a Mesozoic tile, but there are still %v", len(g.MesozoicTileKeys))
+		}
+		return
+	}
+	t.Fatal("There are no cards to buy.")
+}
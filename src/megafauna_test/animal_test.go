@@ -70,3 +70,53 @@ func TestHerbivoreContestBasicTests(t *testing.T) {
 	}
 
 }
+
+func TestHerbivoreContestResolve(t *testing.T) {
+	// animal1 is suited and in the niche, animal2 is suited, and animal3 is not suited
+	animal1 := megafauna.Animal{2, 2, megafauna.MakeDNASpec("BBI"), nil, 0}
+	animal2 := megafauna.Animal{5, 2, megafauna.MakeDNASpec("BB"), nil, 1}
+	animal3 := megafauna.Animal{4, 3, megafauna.MakeDNASpec("GG"), nil, 2}
+
+	niche, err := megafauna.MakeNiche("I")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &megafauna.HerbivoreContest{
+		Animals:      []*megafauna.Animal{&animal3, &animal2, &animal1},
+		Requirements: megafauna.MakeDNASpec("BB"),
+		Niche:        niche,
+	}
+	result := h.Resolve()
+	if result.Winner != &animal1 {
+		t.Fatalf("animal1 should have won on its niche, got %v", result.Winner)
+	}
+	expected := []megafauna.ContestEntry{
+		{Animal: &animal1, Suitability: 100, Niche: 10, Dentition: 2, Score: 112},
+		{Animal: &animal2, Suitability: 100, Niche: 0, Dentition: 5, Score: 105, Eliminated: megafauna.EliminatedOutscored},
+		{Animal: &animal3, Suitability: 0, Niche: 0, Dentition: 4, Score: 4, Eliminated: megafauna.EliminatedUnsuitable},
+	}
+	if len(result.Entries) != len(expected) {
+		t.Fatalf("Expected %v entries, got %v", len(expected), len(result.Entries))
+	}
+	for i, e := range result.Entries {
+		if *e != expected[i] {
+			t.Errorf("Expected entry %v to be %+v, got %+v", i, expected[i], *e)
+		}
+	}
+
+	// nobody wins if nobody is suited
+	h.Requirements = megafauna.MakeDNASpec("HH")
+	result = h.Resolve()
+	if result.Winner != nil {
+		t.Errorf("Expected no winner, got %v", result.Winner)
+	}
+	for _, e := range result.Entries {
+		if e.Eliminated != megafauna.EliminatedUnsuitable {
+			t.Errorf("Expected %+v to be unsuitable", *e)
+		}
+	}
+
+	if result = new(megafauna.HerbivoreContest).Resolve(); result.Winner != nil || len(result.Entries) != 0 {
+		t.Errorf("Expected an empty result for an empty contest, got %+v", result)
+	}
+}
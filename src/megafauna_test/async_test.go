@@ -0,0 +1,119 @@
+package megafauna_test
+
+import (
+	"megafauna"
+	"testing"
+	"time"
+)
+
+// testNotifier records what it's told.
+type testNotifier struct {
+	started  []int // dentitions
+	timedOut []int // dentitions
+	isOver   bool
+}
+
+func (n *testNotifier) TurnStarted(g *megafauna.Game, p *megafauna.Player, deadline time.Time) {
+	n.started = append(n.started, p.Dentition)
+}
+
+func (n *testNotifier) TurnTimedOut(g *megafauna.Game, p *megafauna.Player, a *megafauna.Action) {
+	n.timedOut = append(n.timedOut, p.Dentition)
+}
+
+func (n *testNotifier) GameOver(g *megafauna.Game) {
+	n.isOver = true
+}
+
+func TestAsyncGame(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	n := new(testNotifier)
+	a := megafauna.NewAsyncGameWithClock(g, 24*time.Hour, megafauna.AutoPass, n, clock)
+
+	first, second := g.Players[0], g.Players[1]
+	if len(n.started) != 1 || n.started[0] != first.Dentition {
+		t.Fatalf("Expected the first player to be notified, got %v", n.started)
+	}
+	if !a.Deadline.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("Unexpected deadline %v", a.Deadline)
+	}
+
+	// nothing happens before the deadline
+	now = now.Add(23 * time.Hour)
+	if taken, err := a.Expire(); err != nil || len(taken) != 0 {
+		t.Fatalf("Expected no auto-actions, got %v, %v", taken, err)
+	}
+
+	// the first player moves in time
+	if err := a.Apply(megafauna.AutoPass(g, first.Dentition)); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Deadline.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("The deadline should be a day after the move, got %v", a.Deadline)
+	}
+
+	// and then nobody does anything for two and a half days
+	genes := second.Genes
+	now = now.Add(60 * time.Hour)
+	taken, err := a.Expire()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taken) != 2 || len(n.timedOut) != 2 || n.timedOut[0] != second.Dentition || n.timedOut[1] != first.Dentition {
+		t.Fatalf("Expected both players to time out, got %v", n.timedOut)
+	}
+	if second.Genes != genes+1 {
+		t.Error("The auto-action should have passed for the second player.")
+	}
+	if g.GetActivePlayer() != second || !a.Deadline.After(now) {
+		t.Errorf("Expected the second player's turn, with a deadline after %v, got %v", now, a.Deadline)
+	}
+}
+
+func TestAsyncGame_BadAutoAction(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	never := func(g *megafauna.Game, dentition int) *megafauna.Action { return nil }
+	now := time.Now()
+	a := megafauna.NewAsyncGameWithClock(g, time.Minute, never, nil, func() time.Time { return now })
+	now = now.Add(time.Hour)
+	if _, err := a.Expire(); err != megafauna.ErrNoAutoAction {
+		t.Errorf("Expected ErrNoAutoAction, got %v", err)
+	}
+}
+
+func TestAutoFirstAction(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mutations, genotypes []string
+	for key, c := range g.Cards {
+		if c.Mutation != nil {
+			mutations = append(mutations, key)
+		} else {
+			genotypes = append(genotypes, key)
+		}
+	}
+	p := g.GetActivePlayer()
+
+	// with no species, a mutation is no use, so the genotype is bought even though it costs more
+	g.LowerDisplayCardKeys = []string{mutations[0], genotypes[0], mutations[1]}
+	a := megafauna.AutoFirstAction(g, p.Dentition)
+	if a.Type != megafauna.ActionBuyCard || a.CardKey != genotypes[0] || a.Silhouette < 0 {
+		t.Errorf("Expected to buy %v for a new species, got %+v", genotypes[0], a)
+	}
+
+	// and if there's nothing the player can use, they pass
+	g.LowerDisplayCardKeys = []string{mutations[0], mutations[1]}
+	if a = megafauna.AutoFirstAction(g, p.Dentition); a.Type != megafauna.ActionPass {
+		t.Errorf("Expected to pass rather than buy a mutation for its genes, got %+v", a)
+	}
+}
@@ -2,6 +2,7 @@ package megafauna_test
 
 import (
 	"megafauna"
+	"strings"
 	"testing"
 )
 
@@ -179,3 +180,118 @@ func TestFindLowestClimax(t *testing.T) {
 		t.Errorf("Expected to find T6, but found %v.", h.Key)
 	}
 }
+
+func TestPlaceTileOnBoard(t *testing.T) {
+	// a board with one Tropics habitat, so every Tropics tile goes there
+	layout := make(megafauna.BoardLayout, 0)
+	err := layout.Parse(strings.NewReader("X0,T,1,FALSE,,,,\n"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	b, err := layout.NewBoard()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	h := b.Habitats[0][0]
+	tiles, err := megafauna.GetTiles()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var first, second, immigrant *megafauna.Tile
+	for _, tile := range tiles {
+		switch {
+		case tile.IsImmigrantTile():
+			immigrant = tile
+		case tile.LatitudeKey == "T" && first == nil:
+			first = tile
+		case tile.LatitudeKey == "T" && second == nil:
+			second = tile
+		}
+	}
+
+	// a tile in an empty habitat starts a biome there
+	displaced, err := b.PlaceTileOnBoard(first)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if displaced != nil || h.Biome == nil || h.Biome.Tile != first {
+		t.Errorf("Expected %v to start a biome in %v.", first.Key, h.Key)
+		return
+	}
+
+	// the next tile for the latitude replaces it, and the old tile is returned
+	displaced, err = b.PlaceTileOnBoard(second)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if displaced != first || h.Biome.Tile != second {
+		t.Errorf("Expected %v to displace %v from %v.", second.Key, first.Key, h.Key)
+	}
+
+	if _, err = b.PlaceTileOnBoard(immigrant); err != megafauna.ErrNotBiomeTile {
+		t.Errorf("Expected ErrNotBiomeTile for an immigrant tile, got %v.", err)
+	}
+}
+
+func TestBoardLayout(t *testing.T) {
+	// a tiny board with one row of three habitats, the middle one an orogeny
+	data := "X0,A,3,FALSE,,X1,,\nX1,A,1,TRUE,,X2,,X0\nX2,A,2,FALSE,,,,X1\n"
+	layout := make(megafauna.BoardLayout, 0)
+	err := layout.Parse(strings.NewReader(data))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	b, err := layout.NewBoard()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(b.Habitats) != 1 || len(b.Habitats[0]) != 3 {
+		t.Error("Expected one row of three habitats.")
+		return
+	}
+	if len(b.LatitudeMap["O"].Habitats) != 1 || b.LatitudeMap["O"].Habitats[0].Key != "X1" {
+		t.Error("Expected X1 to be the only orogeny habitat.")
+	}
+	h, _ := b.FindLowestClimax("A")
+	if h == nil || h.Key != "X1" {
+		t.Error("Expected X1 to have the lowest climax number.")
+	}
+
+	// X2 doesn't point back to X1
+	data = "X0,A,3,FALSE,,X1,,\nX1,A,1,TRUE,,X2,,X0\nX2,A,2,FALSE,,,,\n"
+	layout = make(megafauna.BoardLayout, 0)
+	err = layout.Parse(strings.NewReader(data))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	_, err = layout.NewBoard()
+	report, ok := err.(megafauna.ValidationReport)
+	if !ok || len(report) != 1 || report[0].Field != "X1" {
+		t.Errorf("Expected an asymmetric adjacency at X1, got %v.", err)
+	}
+
+	// X3 doesn't exist
+	data = "X0,A,3,FALSE,,X3,,\n"
+	layout = make(megafauna.BoardLayout, 0)
+	layout.Parse(strings.NewReader(data))
+	_, err = layout.NewBoard()
+	if err == nil {
+		t.Error("Expected an error for a missing habitat.")
+	}
+}
+
+func TestNewBoard_CheckAdjacencySymmetry(t *testing.T) {
+	b := megafauna.NewBoard()
+	err := b.CheckAdjacency()
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,165 @@
+package megafauna_test
+
+import (
+	"math/rand"
+	"megafauna"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPlayBots_Random(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(1))
+	bots := make(map[int]megafauna.Bot)
+	for _, p := range g.Players {
+		bots[p.Dentition] = megafauna.NewRandomBot(r)
+	}
+	taken, err := g.PlayBots(bots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.IsOver || g.Winner() == nil {
+		t.Fatal("Expected the bots to play the game to the end.")
+	}
+	if len(taken) != len(g.Actions) {
+		t.Errorf("Expected the bots to take all %v actions, got %v", len(g.Actions), len(taken))
+	}
+}
+
+func TestPlayBots_Mixed(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"Human", "Bot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	human, bot := g.Players[0], g.Players[1]
+	bots := map[int]megafauna.Bot{bot.Dentition: megafauna.NewGreedyBot(g.Rules, rand.New(rand.NewSource(1)))}
+
+	// it's the human's turn, so the bot waits
+	taken, err := g.PlayBots(bots)
+	if err != nil || len(taken) != 0 {
+		t.Fatalf("Expected the bot to wait for the human, got %v, %v", taken, err)
+	}
+	for turn := 0; turn < 5 && !g.IsOver; turn++ {
+		if err := g.Apply(megafauna.AutoPass(g, human.Dentition)); err != nil {
+			t.Fatal(err)
+		}
+		taken, err = g.PlayBots(bots)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !g.IsOver && (len(taken) != 1 || taken[0].Dentition != bot.Dentition) {
+			t.Fatalf("Expected the bot to take one action, got %v", taken)
+		}
+	}
+}
+
+func TestGreedyBot(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(3)), 10)
+	if g.IsOver {
+		t.Skip("The game ended early.")
+	}
+	before := len(g.Actions)
+	p := g.GetActivePlayer()
+	legal := g.LegalActions(p.Dentition)
+	a := megafauna.NewGreedyBot(g.Rules, rand.New(rand.NewSource(1))).ChooseAction(g.ViewFor(p.Dentition), legal)
+	if a == nil || !containsAction(legal, a) {
+		t.Fatalf("Expected a legal action, got %v", a)
+	}
+	if len(g.Actions) != before {
+		t.Error("The greedy bot changed the game.")
+	}
+
+}
+
+func TestNewGameFromView(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := megafauna.NewGameWithSeed(rules, []megafauna.Seat{{Name: "A", Dentition: 3}, {Name: "B", Dentition: 4}}, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(7)), 20)
+	if g.IsOver {
+		t.Fatal("The game ended early.")
+	}
+	p := g.GetActivePlayer()
+	view := g.ViewFor(p.Dentition)
+	made, err := megafauna.NewGameFromView(rules, view, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(made.ViewFor(p.Dentition), view) {
+		t.Error("The game made from the view doesn't look like the view.")
+	}
+	if !reflect.DeepEqual(made.LegalActions(p.Dentition), g.LegalActions(p.Dentition)) {
+		t.Error("The game made from the view has different legal actions.")
+	}
+
+	// the hidden cards are the same ones, dealt out again
+	hidden := func(g *megafauna.Game) []string {
+		keys := make([]string, 0)
+		for _, stack := range [][]string{g.CardKeys, g.TriassicCardKeys, g.JurassicCardKeys, g.CretaceousCardKeys, g.TertiaryCardKeys} {
+			keys = append(keys, stack...)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	if !reflect.DeepEqual(hidden(made), hidden(g)) {
+		t.Error("The game made from the view has different hidden cards.")
+	}
+	again, err := megafauna.NewGameFromView(rules, view, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again.MesozoicTileKeys, made.MesozoicTileKeys) {
+		t.Error("The same source of random numbers should deal the tiles out the same way.")
+	}
+	playRandomly(made, rand.New(rand.NewSource(1)), 1000)
+	if !made.IsOver {
+		t.Error("Expected the game made from the view to play to the end.")
+	}
+
+	view.MesozoicTiles++
+	if _, err := megafauna.NewGameFromView(rules, view, rand.New(rand.NewSource(1))); err != megafauna.ErrInvalidView {
+		t.Errorf("Expected ErrInvalidView for a view with too many tiles, got %v", err)
+	}
+}
+
+func TestBotAutoAction(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	auto := megafauna.BotAutoAction(megafauna.NewRandomBot(rand.New(rand.NewSource(1))))
+	a := megafauna.NewAsyncGameWithClock(g, time.Hour, auto, nil, func() time.Time { return now })
+	now = now.Add(3*time.Hour + time.Minute)
+	taken, err := a.Expire()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taken) != 3 && !g.IsOver {
+		t.Errorf("Expected 3 auto-actions, got %v", len(taken))
+	}
+}
+
+// containsAction tells you whether a is one of the actions.
+func containsAction(actions []*megafauna.Action, a *megafauna.Action) bool {
+	for _, other := range actions {
+		if *other == *a {
+			return true
+		}
+	}
+	return false
+}
@@ -2,7 +2,9 @@ package megafauna_test
 
 import (
 	"megafauna"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestCards(t *testing.T) {
@@ -104,3 +106,52 @@ func checkGenotypeCards(t *testing.T, cards map[string]*megafauna.Card) {
 		t.Error("Didn't parse event data correctly.")
 	}
 }
+
+func TestGetCardsFrom(t *testing.T) {
+	mutations := strings.NewReader("M1,1,6,S,,Breathing while running,Carrier's Constant Diaphragm,,T,,,,")
+	genotypes := strings.NewReader("G2,cat,Pholidota,Pangolins,,1,2,IA,fin,Crurotarsi,Aetosaurs,,1,3,AN,T,,,,")
+	cards, err := megafauna.GetCardsFrom(mutations, genotypes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(cards) != 2 || cards["M1"] == nil || cards["G2"] == nil {
+		t.Errorf("Expected cards M1 and G2, got %v.", cards)
+	}
+}
+
+func TestGetCardsFS(t *testing.T) {
+	// an errata data set that only replaces the genotype cards
+	fsys := fstest.MapFS{
+		megafauna.GenotypeCardsFile: &fstest.MapFile{
+			Data: []byte("G2,cat,Pholidota,Pangolins,,1,3,IA,fin,Crurotarsi,Aetosaurs,,1,3,AN,T,,,,"),
+		},
+	}
+	cards, err := megafauna.GetCardsFS(fsys)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if cards["M66"] == nil {
+		t.Error("Expected the embedded mutation cards to be used.")
+	}
+	if cards["G3"] != nil {
+		t.Error("Expected the embedded genotype cards to be replaced.")
+	}
+	if cards["G2"] == nil || cards["G2"].Genotype.MammalData.MaxSize != 3 {
+		t.Error("Expected G2 to come from the errata file.")
+	}
+}
+
+func TestCatastropheIsWarming(t *testing.T) {
+	cards, err := megafauna.GetCards()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// M12 is "Volcanic acid rain, global warming"
+	e := cards["M12"].Event
+	if !e.IsCatastrophe || !e.IsWarming || e.IsCooling {
+		t.Error("M12 should be a global-warming catastrophe.")
+	}
+}
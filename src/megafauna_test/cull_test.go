@@ -0,0 +1,152 @@
+package megafauna_test
+
+import (
+	"megafauna"
+	"testing"
+)
+
+// populateHomeland founds species 0 for each player, and puts one of its animals in the herbivore slot of
+// the first player's homeland.
+func populateHomeland(t *testing.T, g *megafauna.Game) *megafauna.Biome {
+	h := g.Board.FindLowestBiome(g.Players[0].HomelandTile.LatitudeKey)
+	if h == nil {
+		t.Fatal("The first player's homeland isn't on the board.")
+	}
+	for _, p := range g.Players {
+		p.Genomes[0] = megafauna.MakeDNASpec("")
+		p.SpeciesSizes[0] = 1
+		a := &megafauna.Animal{Dentition: p.Dentition, Size: 1, Genome: p.Genomes[0]}
+		p.Species[0] = append(p.Species[0], a)
+		p.AnimalTokens[0]--
+		h.Biome.Herbivore = append(h.Biome.Herbivore, a)
+	}
+	return h.Biome
+}
+
+func TestCull_Herbivores(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := populateHomeland(t, g)
+	g.Cull(g.Players[0].HomelandTile.LatitudeKey)
+
+	if len(b.Herbivore) != 1 {
+		t.Fatalf("Expected one herbivore to survive, got %v", len(b.Herbivore))
+	}
+	survivor := b.Herbivore[0]
+	for _, p := range g.Players {
+		if p.Dentition == survivor.Dentition {
+			if !p.HasSpecies(0) || len(p.Species[0]) != 1 {
+				t.Errorf("%v's species should have survived.", p)
+			}
+			continue
+		}
+		if p.HasSpecies(0) {
+			t.Errorf("%v's species should be extinct.", p)
+		}
+		if p.AnimalTokens[0] != 8 {
+			t.Errorf("%v's animal should be back in the supply.", p)
+		}
+	}
+}
+
+func TestCull_OtherLatitudes(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := populateHomeland(t, g)
+	for key, lat := range g.Board.LatitudeMap {
+		inLatitude := false
+		for _, h := range lat.Habitats {
+			inLatitude = inLatitude || h == b.Habitat
+		}
+		if !inLatitude {
+			g.Cull(key)
+		}
+	}
+	if len(b.Herbivore) != 2 {
+		t.Errorf("Culling other latitudes shouldn't affect %v.", b.Key)
+	}
+}
+
+func TestWinner(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Winner() != nil {
+		t.Error("There shouldn't be a winner before the game is over.")
+	}
+	g.IsOver = true
+	fewest, most := g.Players[0], g.Players[0]
+	for _, p := range g.Players {
+		p.Score = 5
+		if p.Dentition < fewest.Dentition {
+			fewest = p
+		}
+		if p.Dentition > most.Dentition {
+			most = p
+		}
+	}
+	if w := g.Winner(); w != fewest {
+		t.Errorf("Ties should go to %v, who has the fewest teeth, got %v", fewest, w)
+	}
+	most.Score = 6
+	if w := g.Winner(); w != most {
+		t.Errorf("Expected %v to win, got %v", most, w)
+	}
+}
+
+func TestCatastrophe_Extinction(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var catastrophe *megafauna.Card
+	for _, c := range g.Cards {
+		if c.Event.IsCatastrophe && (catastrophe == nil || c.Key < catastrophe.Key) {
+			catastrophe = c
+		}
+	}
+	if catastrophe == nil {
+		t.Fatal("There are no catastrophe cards.")
+	}
+	b := populateHomeland(t, g)
+	for _, a := range b.Herbivore {
+		a.Size = catastrophe.Event.CatastropheLevel
+	}
+	extinctions := 0
+	g.AddObserver(megafauna.ObserverFunc(func(g *megafauna.Game, e *megafauna.GameEvent) {
+		if e.Type == megafauna.EventSpeciesExtinct {
+			extinctions++
+		}
+	}))
+
+	// draw the catastrophe next, with no tiles left to place, so that no immigrant can arrive in the homeland
+	g.TriassicCardKeys = append([]string{catastrophe.Key}, g.TriassicCardKeys...)
+	g.MesozoicTileKeys = nil
+	g.CenozoicTileKeys = nil
+	p := g.GetActivePlayer()
+	for _, a := range g.LegalActions(p.Dentition) {
+		if a.Type == megafauna.ActionBuyCard {
+			if err := g.Apply(a); err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if len(b.Herbivore) != 0 {
+		t.Fatalf("Expected the catastrophe to kill every herbivore in %v, got %v", b.Key, len(b.Herbivore))
+	}
+	for _, p := range g.Players {
+		if p.HasSpecies(0) {
+			t.Errorf("%v's species should be extinct.", p)
+		}
+	}
+	if extinctions != len(g.Players) {
+		t.Errorf("Expected %v extinctions, got %v", len(g.Players), extinctions)
+	}
+}
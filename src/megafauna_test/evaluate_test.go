@@ -0,0 +1,61 @@
+package megafauna_test
+
+import (
+	"math"
+	"math/rand"
+	"megafauna"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(4)), 20)
+	before := g.ViewFor(0)
+	p := g.Players[0]
+	value := megafauna.Evaluate(g, p.Dentition)
+	if !reflect.DeepEqual(before, g.ViewFor(0)) {
+		t.Error("Evaluate changed the game.")
+	}
+	if megafauna.Evaluate(g, 6) != 0 {
+		t.Error("Expected 0 for a player who isn't in the game.")
+	}
+
+	// each term is weighted
+	p.Genes += 2
+	if got, want := megafauna.Evaluate(g, p.Dentition), value+2*megafauna.DefaultEvalWeights.Genes; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected two more genes to be worth %v, got %v", want, got)
+	}
+	w := megafauna.EvalWeights{Score: 1}
+	p.Score = 7
+	if got := w.Evaluate(g, p.Dentition); got != 7 {
+		t.Errorf("Expected only the score to count, got %v", got)
+	}
+}
+
+func TestParseEvalWeights(t *testing.T) {
+	w, err := megafauna.ParseEvalWeightsJSON(strings.NewReader(`{"genes": 0.5, "biomes": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := megafauna.DefaultEvalWeights
+	want.Genes, want.Biomes = 0.5, 2
+	if *w != want {
+		t.Errorf("Expected %+v, got %+v", want, *w)
+	}
+	if _, err := megafauna.ParseEvalWeightsJSON(strings.NewReader(`{"teeth": 1}`)); err == nil {
+		t.Error("Expected an error for an unknown weight.")
+	}
+
+	w, err = megafauna.ParseEvalWeightsYAML(strings.NewReader("# tuning\ngenes: 0.5\nbiomes: 2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *w != want {
+		t.Errorf("Expected %+v, got %+v", want, *w)
+	}
+}
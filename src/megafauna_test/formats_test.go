@@ -0,0 +1,191 @@
+package megafauna_test
+
+import (
+	"bytes"
+	"errors"
+	"megafauna"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCardsJSONAndYAML(t *testing.T) {
+	cards, err := megafauna.GetCards()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = megafauna.WriteCardsJSON(&buf, cards)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromJSON, err := megafauna.ParseCardsJSON(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(cards, fromJSON) {
+		t.Error("Cards read from JSON don't match the CSV data.")
+	}
+
+	buf.Reset()
+	err = megafauna.WriteCardsYAML(&buf, cards)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromYAML, err := megafauna.ParseCardsYAML(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(cards, fromYAML) {
+		t.Error("Cards read from YAML don't match the CSV data.")
+	}
+}
+
+func TestTilesJSONAndYAML(t *testing.T) {
+	tiles, err := megafauna.GetTiles()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = megafauna.WriteTilesJSON(&buf, tiles)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromJSON, err := megafauna.ParseTilesJSON(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(tiles, fromJSON) {
+		t.Error("Tiles read from JSON don't match the CSV data.")
+	}
+
+	buf.Reset()
+	err = megafauna.WriteTilesYAML(&buf, tiles)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromYAML, err := megafauna.ParseTilesYAML(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(tiles, fromYAML) {
+		t.Error("Tiles read from YAML don't match the CSV data.")
+	}
+}
+
+func TestInheritanceTilesAndLatitudesYAML(t *testing.T) {
+	inheritanceTiles := megafauna.GetInheritanceTiles()
+	var buf bytes.Buffer
+	err := megafauna.WriteInheritanceTilesYAML(&buf, inheritanceTiles)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	fromYAML, err := megafauna.ParseInheritanceTilesYAML(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !reflect.DeepEqual(inheritanceTiles, fromYAML) {
+		t.Error("Inheritance tiles read from YAML don't match the CSV data.")
+	}
+
+	data := `# the board's latitudes
+- key: T
+  name: Tropics
+- {key: H, name: Horse Latitudes}
+`
+	_, err = megafauna.ParseLatitudesYAML(strings.NewReader(data))
+	if !errors.Is(err, megafauna.ErrInvalidYAML) {
+		t.Errorf("Expected flow mappings to be rejected, got %v.", err)
+	}
+
+	data = `# the board's latitudes
+- key: T
+  name: Tropics
+- key: H
+  name: 'Horse Latitudes' # quoted
+`
+	latitudes, err := megafauna.ParseLatitudesYAML(strings.NewReader(data))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(latitudes) != 2 || latitudes["H"].Name != "Horse Latitudes" {
+		t.Errorf("Latitudes weren't parsed correctly: %v", latitudes)
+	}
+}
+
+func TestParseTilesYAML_PlainScalars(t *testing.T) {
+	// numbers are only numbers where the schema has a number; a niche of 3 and a title of 1984 are strings
+	data := `- key: MT1
+  mesozoic: true
+  latitude: T
+  title: 1984
+  land: true
+  biome:
+    climaxNumber: 12
+    requirements: ""
+    niche: 3
+`
+	tiles, err := megafauna.ParseTilesYAML(strings.NewReader(data))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tile := tiles["MT1"]
+	if tile == nil || tile.Title != "1984" || tile.BiomeData.ClimaxNumber != 12 || tile.BiomeData.Niche.Dentition != 3 {
+		t.Errorf("The tile wasn't parsed correctly: %+v", tile)
+	}
+}
+
+func TestParseCardsJSON_InvalidEvent(t *testing.T) {
+	data := `[
+		{"key": "M1", "mutation": {"minSize": 1, "maxSize": 6, "mutation": "S", "title": "Diaphragm"}, "event": {"type": "milankovich", "milankovichLatitudes": ["T", "X"]}}
+	]`
+	_, err := megafauna.ParseCardsJSON(strings.NewReader(data))
+	e, ok := err.(*megafauna.DataError)
+	if !ok {
+		t.Errorf("Expected a DataError, got %v.", err)
+		return
+	}
+	if e.Field != "event.milankovichLatitudes" || !errors.Is(e, megafauna.ErrInvalidLatitudeKey) {
+		t.Errorf("Expected an invalid latitude in event.milankovichLatitudes, got %v.", e)
+	}
+
+	data = `[
+		{"key": "M1", "mutation": {"minSize": 1, "maxSize": 6, "mutation": "S", "title": "Diaphragm"}, "event": {"type": "meteor"}}
+	]`
+	_, err = megafauna.ParseCardsJSON(strings.NewReader(data))
+	if e, ok = err.(*megafauna.DataError); !ok || e.Field != "event.type" {
+		t.Errorf("Expected an invalid event.type, got %v.", err)
+	}
+}
+
+func TestParseCardsJSON_Invalid(t *testing.T) {
+	data := `[
+		{"key": "M1", "mutation": {"minSize": 1, "maxSize": 6, "mutation": "S", "title": "Diaphragm"}, "event": {"type": "drawTwo"}},
+		{"key": "M2", "mutation": {"minSize": 1, "maxSize": 4, "mutation": "SX", "title": "Lungs"}, "event": {"type": "drawTwo"}}
+	]`
+	_, err := megafauna.ParseCardsJSON(strings.NewReader(data))
+	e, ok := err.(*megafauna.DataError)
+	if !ok {
+		t.Errorf("Expected a DataError, got %v.", err)
+		return
+	}
+	if e.Line != 2 || e.Field != "mutation" || !errors.Is(e, megafauna.ErrInvalidDNASpec) {
+		t.Errorf("Expected an invalid DNA spec in entry 2, got %v.", e)
+	}
+}
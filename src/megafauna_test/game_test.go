@@ -2,6 +2,7 @@ package megafauna_test
 
 import (
 	"megafauna"
+	"reflect"
 	"sort"
 	"testing"
 )
@@ -123,3 +124,59 @@ func TestGetPlayer(t *testing.T) {
 		}
 	}
 }
+
+func TestNewGame_Setup(t *testing.T) {
+	for _, names := range [][]string{{"A", "B"}, {"A", "B", "C"}, {"A", "B", "C", "D"}} {
+		g, err := megafauna.NewGame(names)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// the Triassic stack has three cards per player, so the players have to be created before the cards
+		if len(g.TriassicCardKeys) != 3*len(names) {
+			t.Errorf("Expected %v Triassic cards for %v players, got %v.", 3*len(names), len(names), len(g.TriassicCardKeys))
+		}
+		// the homeland tiles are added to the tiles, so the tiles have to be created before the players, and then
+		// they're put on the board
+		for _, p := range g.Players {
+			if g.Tiles[p.HomelandTile.Key] != p.HomelandTile {
+				t.Errorf("%v's homeland tile isn't in the game's tiles.", p)
+			}
+			found := false
+			for _, b := range g.Board.Biomes() {
+				if b.Tile == p.HomelandTile {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("%v's homeland tile isn't on the board.", p)
+			}
+		}
+	}
+}
+
+func TestNewGameWithSeed(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seats := []megafauna.Seat{{Name: "A", Dentition: 2}, {Name: "B", Dentition: 5}}
+	g1, err := megafauna.NewGameWithSeed(rules, seats, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2, err := megafauna.NewGameWithSeed(rules, seats, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g1.Setup, g2.Setup) {
+		t.Error("Expected the same seed to set up the same game.")
+	}
+	g3, err := megafauna.NewGameWithSeed(rules, seats, 43)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(g1.Setup, g3.Setup) {
+		t.Error("Expected different seeds to set up different games.")
+	}
+}
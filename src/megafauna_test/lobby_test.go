@@ -0,0 +1,128 @@
+package megafauna_test
+
+import (
+	"megafauna"
+	"testing"
+)
+
+func TestLobby_Join(t *testing.T) {
+	l := megafauna.NewLobby()
+	for _, name := range []string{"A", "B", "C", "D"} {
+		if err := l.Join(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Join("A"); err != megafauna.ErrNameTaken {
+		t.Errorf("Expected ErrNameTaken, got %v", err)
+	}
+	if err := l.Join("E"); err != megafauna.ErrLobbyFull {
+		t.Errorf("Expected ErrLobbyFull, got %v", err)
+	}
+	if err := l.Leave("D"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Leave("D"); err != megafauna.ErrNotInLobby {
+		t.Errorf("Expected ErrNotInLobby, got %v", err)
+	}
+	if err := l.Join(""); err != megafauna.ErrEmptyName {
+		t.Errorf("Expected ErrEmptyName, got %v", err)
+	}
+}
+
+func TestLobby_Claims(t *testing.T) {
+	l := megafauna.NewLobby()
+	for _, name := range []string{"A", "B", "C", "D"} {
+		l.Join(name)
+	}
+	if err := l.ClaimDentition("A", 6); err != megafauna.ErrInvalidDentition {
+		t.Errorf("Expected ErrInvalidDentition, got %v", err)
+	}
+	if err := l.ClaimColor("A", "Green"); err != nil {
+		t.Fatal(err)
+	}
+	if m := l.GetMember("A"); m.Dentition != 4 || m.Side != megafauna.SideDinosaur {
+		t.Errorf("Claiming Green should claim dentition 4 and the dinosaurs, got %+v", m)
+	}
+	if err := l.ClaimDentition("B", 4); err != megafauna.ErrDentitionTaken {
+		t.Errorf("Expected ErrDentitionTaken, got %v", err)
+	}
+	if err := l.ClaimSide("B", megafauna.SideDinosaur); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.ClaimSide("C", megafauna.SideDinosaur); err != megafauna.ErrSideFull {
+		t.Errorf("Expected ErrSideFull, got %v", err)
+	}
+	if err := l.ClaimColor("C", "Purple"); err != megafauna.ErrInvalidColor {
+		t.Errorf("Expected ErrInvalidColor, got %v", err)
+	}
+	if err := l.ClaimDentition("C", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 20; i++ {
+		for _, seat := range l.Seats() {
+			switch seat.Name {
+			case "A":
+				if seat.Dentition != 4 {
+					t.Errorf("A should be seated at 4, got %v", seat.Dentition)
+				}
+			case "B":
+				if seat.Dentition != 2 {
+					t.Errorf("B claimed the dinosaurs, and should be seated at 2, got %v", seat.Dentition)
+				}
+			case "C":
+				if seat.Dentition != 3 {
+					t.Errorf("C should be seated at 3, got %v", seat.Dentition)
+				}
+			case "D":
+				if seat.Dentition != 5 {
+					t.Errorf("D should get the last dentition, 5, got %v", seat.Dentition)
+				}
+			}
+		}
+	}
+}
+
+func TestLobby_Start(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := megafauna.NewLobby()
+	l.Join("A")
+	l.SetReady("A", true)
+	if _, err := l.Start(rules); err != megafauna.ErrNotEnoughPlayers {
+		t.Errorf("Expected ErrNotEnoughPlayers, got %v", err)
+	}
+	l.Join("B")
+	l.ClaimColor("B", "White")
+	if _, err := l.Start(rules); err != megafauna.ErrPlayersNotReady {
+		t.Errorf("Expected ErrPlayersNotReady, got %v", err)
+	}
+	l.SetReady("B", true)
+	g, err := l.Start(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := g.GetPlayer(5); p == nil || p.Name != "B" || p.Color != "White" {
+		t.Errorf("B should be playing White, got %v", p)
+	}
+	if err := l.Join("C"); err != megafauna.ErrLobbyStarted {
+		t.Errorf("Expected ErrLobbyStarted, got %v", err)
+	}
+}
+
+func TestNewGameWithSeats(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = megafauna.NewGameWithSeats(rules, []megafauna.Seat{{Name: "A", Dentition: 2}, {Name: "B", Dentition: 2}})
+	if err != megafauna.ErrInvalidPlayers {
+		t.Errorf("Expected ErrInvalidPlayers for a repeated dentition, got %v", err)
+	}
+	_, err = megafauna.NewGameWithSeats(rules, []megafauna.Seat{{Name: "A", Dentition: 2}, {Name: "B", Dentition: 6}})
+	if err != megafauna.ErrInvalidPlayers {
+		t.Errorf("Expected ErrInvalidPlayers for dentition 6, got %v", err)
+	}
+}
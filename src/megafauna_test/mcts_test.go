@@ -0,0 +1,74 @@
+package megafauna_test
+
+import (
+	"math/rand"
+	"megafauna"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMCTSBot(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := megafauna.NewGameWithSeed(rules, []megafauna.Seat{{Name: "A", Dentition: 2}, {Name: "B", Dentition: 5}}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(5)), 6)
+	if g.IsOver {
+		t.Fatal("The game ended before the search.")
+	}
+	before := g.ViewFor(0)
+	p := g.GetActivePlayer()
+	legal := g.LegalActions(p.Dentition)
+	bot := megafauna.NewMCTSBot(g.Rules, rand.New(rand.NewSource(1)), 50, 0)
+	a := bot.ChooseAction(g.ViewFor(p.Dentition), legal)
+	if a == nil || !containsAction(legal, a) {
+		t.Fatalf("Expected a legal action, got %v", a)
+	}
+	if !reflect.DeepEqual(before, g.ViewFor(0)) {
+		t.Error("The search changed the game.")
+	}
+}
+
+func TestMCTSBot_TimeLimit(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := g.GetActivePlayer()
+	bot := megafauna.NewMCTSBot(g.Rules, rand.New(rand.NewSource(1)), 0, 50*time.Millisecond)
+	start := time.Now()
+	a := bot.ChooseAction(g.ViewFor(p.Dentition), g.LegalActions(p.Dentition))
+	if a == nil {
+		t.Fatal("Expected an action.")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the search to stop after 50ms, took %v", elapsed)
+	}
+}
+
+func TestMCTSBot_Repeatable(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := megafauna.NewGameWithSeed(rules, []megafauna.Seat{{Name: "A", Dentition: 3}, {Name: "B", Dentition: 4}}, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(9)), 40)
+	if g.IsOver {
+		t.Fatal("The game ended before the search.")
+	}
+	p := g.GetActivePlayer()
+	legal := g.LegalActions(p.Dentition)
+	first := megafauna.NewMCTSBot(rules, rand.New(rand.NewSource(1)), 30, 0).ChooseAction(g.ViewFor(p.Dentition), legal)
+	second := megafauna.NewMCTSBot(rules, rand.New(rand.NewSource(1)), 30, 0).ChooseAction(g.ViewFor(p.Dentition), legal)
+	if first == nil || second == nil || *first != *second {
+		t.Errorf("Expected bots with the same source of random numbers to choose the same action, got %v and %v", first, second)
+	}
+}
@@ -0,0 +1,86 @@
+package megafauna_test
+
+import (
+	"megafauna"
+	"reflect"
+	"testing"
+)
+
+func TestMigrationPath(t *testing.T) {
+	board := megafauna.NewBoard()
+	walker := megafauna.MakeDNASpec("B")
+	swimmer := megafauna.MakeDNASpec("BM")
+	runner := megafauna.MakeDNASpec("BS")
+
+	tests := []struct {
+		genome   *megafauna.DNASpec
+		from, to string
+		path     []string
+	}{
+		{walker, "A0", "A2", []string{"A0", "A1", "A2"}},
+		{walker, "A2", "A2", []string{"A2"}},
+		// the orogeny barrier at A4 is still the shortest way, but it's shorter for a runner
+		{walker, "A3", "A5", []string{"A3", "A4", "A5"}},
+		{runner, "A3", "A5", []string{"A3", "A4", "A5"}},
+		// the Tropics' extra row only connects to the rest through T2 and T3
+		{walker, "T6", "H2", []string{"T6", "T2", "H2"}},
+	}
+	for _, test := range tests {
+		path, err := board.MigrationPath(test.genome, test.from, test.to)
+		if err != nil {
+			t.Errorf("%v to %v: %v", test.from, test.to, err)
+			continue
+		}
+		if !reflect.DeepEqual(path, test.path) {
+			t.Errorf("%v to %v: expected %v, got %v", test.from, test.to, test.path, path)
+		}
+	}
+
+	// a sea in A1 is in a walker's way, but not a swimmer's
+	h := board.HabitatMap["A1"]
+	h.Biome = megafauna.NewBiome(h, &megafauna.Tile{Key: "S1", IsSea: true})
+	if path, err := board.MigrationPath(swimmer, "A0", "A2"); err != nil || !reflect.DeepEqual(path, []string{"A0", "A1", "A2"}) {
+		t.Errorf("Expected the swimmer to cross the sea, got %v, %v", path, err)
+	}
+	path, err := board.MigrationPath(walker, "A0", "A2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range path {
+		if key == "A1" {
+			t.Errorf("Expected the walker to go around the sea, got %v", path)
+		}
+	}
+	if _, err := board.MigrationPath(walker, "A0", "A1"); err != megafauna.ErrNoMigrationPath {
+		t.Errorf("Expected ErrNoMigrationPath, got %v", err)
+	}
+	if _, err := board.MigrationPath(walker, "A0", "Z9"); err != megafauna.ErrHabitatNotFound {
+		t.Errorf("Expected ErrHabitatNotFound, got %v", err)
+	}
+}
+
+func TestMigrationReach(t *testing.T) {
+	board := megafauna.NewBoard()
+
+	// A4 is an orogeny habitat, so it takes a walker two steps to get there, and A5 is out of reach
+	reach, err := board.MigrationReach(megafauna.MakeDNASpec("B"), 2, "A3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]int{"A3": 0, "A2": 1, "A4": 2, "A1": 2, "J3": 2, "J2": 2}
+	if !reflect.DeepEqual(reach, expected) {
+		t.Errorf("Expected %v, got %v", expected, reach)
+	}
+
+	// a runner crosses mountains in a step
+	reach, err = board.MigrationReach(megafauna.MakeDNASpec("S"), 2, "A3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reach["A5"] != 2 || reach["J4"] != 2 {
+		t.Errorf("Expected the runner to reach A5 and J4 in 2 steps, got %v", reach)
+	}
+	if _, err := board.MigrationReach(megafauna.MakeDNASpec("S"), 2, "Q1"); err != megafauna.ErrHabitatNotFound {
+		t.Errorf("Expected ErrHabitatNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package megafauna_test
+
+import (
+	"math/rand"
+	"megafauna"
+	"testing"
+)
+
+// eventLog is an Observer that records the events it's told about.
+type eventLog struct {
+	events []*megafauna.GameEvent
+}
+
+func (l *eventLog) Observe(g *megafauna.Game, e *megafauna.GameEvent) {
+	l.events = append(l.events, e)
+}
+
+// count returns the number of events of a type.
+func (l *eventLog) count(t megafauna.GameEventType) int {
+	n := 0
+	for _, e := range l.events {
+		if e.Type == t {
+			n++
+		}
+	}
+	return n
+}
+
+func TestObserver(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := new(eventLog)
+	g.AddObserver(log)
+	biomes := len(g.Board.Biomes())
+	stacks := len(g.TriassicCardKeys) + len(g.JurassicCardKeys) + len(g.CretaceousCardKeys) + len(g.TertiaryCardKeys)
+	playRandomly(g, rand.New(rand.NewSource(6)), 1000)
+	if !g.IsOver {
+		t.Fatal("Expected the game to be over.")
+	}
+
+	// the events should account for the state of the game
+	bought := 0
+	for _, p := range g.Players {
+		bought += len(p.CardKeys)
+	}
+	if n := log.count(megafauna.EventCardBought); n != bought {
+		t.Errorf("Expected %v CardBought events, got %v", bought, n)
+	}
+	if n := log.count(megafauna.EventCardDrawn); n != stacks {
+		t.Errorf("Expected %v CardDrawn events, got %v", stacks, n)
+	}
+	placed, displaced := log.count(megafauna.EventTilePlaced), log.count(megafauna.EventTileDisplaced)
+	if placed == 0 || placed-displaced != len(g.Board.Biomes())-biomes {
+		t.Errorf("Expected the tile events to add up to %v new biomes, got %v placed and %v displaced",
+			len(g.Board.Biomes())-biomes, placed, displaced)
+	}
+
+	animals := make(map[int]int)
+	climate := 0
+	scores := make(map[int]int)
+	for _, e := range log.events {
+		switch e.Type {
+		case megafauna.EventAnimalPlaced:
+			animals[e.Dentition]++
+			if e.HabitatKey == "" || e.Slot == "" {
+				t.Errorf("Expected a habitat and a slot: %+v", e)
+			}
+		case megafauna.EventAnimalCulled:
+			animals[e.Dentition]--
+		case megafauna.EventClimateChanged:
+			climate = e.Climate
+		case megafauna.EventScoreChanged:
+			scores[e.Dentition] = e.Score
+		case megafauna.EventSpeciesExtinct:
+			if e.Dentition < 2 || e.Dentition > 5 {
+				t.Errorf("Unexpected extinction: %+v", e)
+			}
+		}
+	}
+	for _, b := range g.Board.Biomes() {
+		for _, a := range b.Animals() {
+			animals[a.Dentition]--
+		}
+	}
+	for d, n := range animals {
+		if n != 0 {
+			t.Errorf("The animal events for dentition %v are off by %v", d, n)
+		}
+	}
+	if n := log.count(megafauna.EventGameOver); n != 1 || log.events[len(log.events)-1].Dentition != g.Winner().Dentition {
+		t.Errorf("Expected the last event to be the game ending, with %v winning, got %v GameOver events", g.Winner(), n)
+	}
+	if climate != g.Climate {
+		t.Errorf("Expected the last climate change to be to %v, got %v", g.Climate, climate)
+	}
+	for _, p := range g.Players {
+		if scores[p.Dentition] != p.Score {
+			t.Errorf("Expected the last score of %v to be %v, got %v", p.Dentition, p.Score, scores[p.Dentition])
+		}
+	}
+}
+
+func TestObserver_CloneAndRemove(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := new(eventLog)
+	g.AddObserver(log)
+
+	// clones don't tell the original's observers anything
+	clone, err := g.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(clone, rand.New(rand.NewSource(1)), 20)
+	if len(log.events) != 0 {
+		t.Errorf("Expected no events from a clone, got %v", len(log.events))
+	}
+
+	calls := 0
+	counter := megafauna.ObserverFunc(func(g *megafauna.Game, e *megafauna.GameEvent) { calls++ })
+	g.AddObserver(counter)
+	g.RemoveObserver(log)
+	g.RemoveObserver(counter) // can't be done, but doesn't panic
+	playRandomly(g, rand.New(rand.NewSource(1)), 20)
+	if len(log.events) != 0 || calls == 0 {
+		t.Errorf("Expected only the remaining observer to be told about events, got %v and %v", len(log.events), calls)
+	}
+}
@@ -0,0 +1,88 @@
+package megafauna_test
+
+import (
+	"math/rand"
+	"megafauna"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestPredictCull(t *testing.T) {
+	animals := 0
+	for seed := int64(1); seed <= 5; seed++ {
+		g, err := megafauna.NewGame([]string{"A", "B", "C", "D"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		playRandomly(g, rand.New(rand.NewSource(seed)), 25)
+		if g.IsOver {
+			continue
+		}
+		before := g.ViewFor(0)
+		f, err := g.PredictCull()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(before, g.ViewFor(0)) {
+			t.Fatal("PredictCull changed the game.")
+		}
+
+		// the prediction should say what happens when the game is actually culled
+		predicted := make(map[string][]string)
+		for key, fates := range f.Habitats {
+			for _, fate := range fates {
+				if fate.Survives {
+					predicted[key] = append(predicted[key], animalID(fate.Animal))
+					if !strings.HasPrefix(fate.Reason, "wins") && !strings.HasPrefix(fate.Reason, "catches") {
+						t.Errorf("Unexpected reason for a survivor: %v", fate.Reason)
+					}
+				} else if fate.Reason == "" {
+					t.Errorf("Expected a reason for the death of %+v", fate.Animal)
+				}
+				if fate.Suitability != 0 && fate.Suitability != 100 {
+					t.Errorf("Unexpected suitability %v", fate.Suitability)
+				}
+				animals++
+			}
+		}
+		g.Cull()
+		actual := make(map[string][]string)
+		for _, b := range g.ViewFor(0).Biomes {
+			for _, animals := range [][]*megafauna.AnimalView{b.Herbivore, b.Rooter, b.Predator} {
+				for _, a := range animals {
+					actual[b.HabitatKey] = append(actual[b.HabitatKey], animalID(a))
+				}
+			}
+		}
+		for key := range actual {
+			sort.Strings(actual[key])
+			sort.Strings(predicted[key])
+			if !reflect.DeepEqual(actual[key], predicted[key]) {
+				t.Errorf("In %v, expected %v to survive, got %v", key, predicted[key], actual[key])
+			}
+		}
+		if len(actual) != len(predicted) {
+			t.Errorf("Expected survivors in %v habitats, got %v", len(predicted), len(actual))
+		}
+		for _, p := range g.Players {
+			for _, s := range f.Extinct[p.Dentition] {
+				if p.HasSpecies(s) {
+					t.Errorf("Expected species %v of %v to go extinct.", s, p.Dentition)
+				}
+			}
+		}
+	}
+	if animals == 0 {
+		t.Error("Expected some animals on the board to predict the fate of.")
+	}
+}
+
+// animalID identifies an animal on the board.
+func animalID(a *megafauna.AnimalView) string {
+	if a.ImmigrantKey != "" {
+		return a.ImmigrantKey
+	}
+	return string(rune('0'+a.Dentition)) + "/" + string(rune('0'+a.Silhouette))
+}
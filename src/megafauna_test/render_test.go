@@ -0,0 +1,62 @@
+package megafauna_test
+
+import (
+	"bytes"
+	"megafauna"
+	"strings"
+	"testing"
+)
+
+func TestBoardRender(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := g.Players[0]
+	h := g.Board.FindLowestBiome(p.HomelandTile.LatitudeKey)
+	h.Biome.Herbivore = append(h.Biome.Herbivore, &megafauna.Animal{Dentition: p.Dentition, Silhouette: 2, Genome: megafauna.MakeDNASpec("")})
+
+	var b bytes.Buffer
+	if err := g.Board.Render(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, s := range []string{"Tropics (T)", "|T7 2 ", "|J1 1 ^", "H: " + p.Color[:1] + "2"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected the board to contain %q:\n%v", s, out)
+		}
+	}
+
+	// the extra row of the Tropics is below T2 and T3
+	lines := strings.Split(out, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "|T6 1") {
+			above := lines[i-9]
+			if strings.Index(line, "|T6") != strings.Index(above, "|T2") {
+				t.Errorf("T6 isn't below T2:\n%v", out)
+			}
+		}
+	}
+}
+
+func TestBoardRender_Disconnected(t *testing.T) {
+	// two habitats that aren't adjacent to each other
+	layout := make(megafauna.BoardLayout, 0)
+	if err := layout.Parse(strings.NewReader("X0,T,1,FALSE,,,,\nY0,A,2,FALSE,,,,\n")); err != nil {
+		t.Fatal(err)
+	}
+	board, err := layout.NewBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := board.Render(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, s := range []string{"|X0 1", "|Y0 2"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected the board to contain %q:\n%v", s, out)
+		}
+	}
+}
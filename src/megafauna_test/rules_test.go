@@ -0,0 +1,132 @@
+package megafauna_test
+
+import (
+	"io"
+	"megafauna"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestInit(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(rules.Cards) != 92 {
+		t.Errorf("Expected 92 cards, got %v.", len(rules.Cards))
+	}
+	if rules.Tiles["MH43"] == nil || rules.Tiles["M16"] == nil {
+		t.Error("Expected both biome and immigrant tiles.")
+	}
+	if len(rules.InheritanceTiles) != 5 {
+		t.Errorf("Expected 5 inheritance tiles, got %v.", len(rules.InheritanceTiles))
+	}
+	if rules.Latitudes["J"] == nil || rules.Latitudes["J"].Name != "Jet Stream" {
+		t.Error("Expected the latitudes to be parsed.")
+	}
+}
+
+func TestInit_Sources(t *testing.T) {
+	sources := map[string]io.Reader{
+		megafauna.GenotypeCardsFile: strings.NewReader("G2,cat,Pholidota,Pangolins,,1,2,IA,fin,Crurotarsi,Aetosaurs,,1,3,AN,T,,,,"),
+		megafauna.LatitudesFile:     strings.NewReader("A,Polar\nJ,Jet Stream\nH,Horse Latitude\nT,Tropics\nO,Orogeny"),
+	}
+	rules, err := megafauna.Init(sources)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(rules.Cards) != 67 {
+		t.Errorf("Expected 66 mutation cards plus G2, got %v cards.", len(rules.Cards))
+	}
+
+	g, err := megafauna.NewGameWithRules(rules, []string{"Tinker", "Evers", "Chance"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if g.Cards["G3"] != nil || g.Cards["G2"] == nil {
+		t.Error("The game's cards should come from the RuleSet.")
+	}
+	if g.Board.LatitudeMap["A"].Name != "Polar" {
+		t.Error("The board's latitude names should come from the RuleSet.")
+	}
+	if len(rules.Tiles) == len(g.Tiles) {
+		t.Error("Homeland tiles shouldn't be added to the RuleSet's tiles.")
+	}
+
+	_, err = megafauna.Init(map[string]io.Reader{"bogus.csv": strings.NewReader("")})
+	if err == nil {
+		t.Error("Expected an error for an unknown source.")
+	}
+}
+
+func TestInitFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		megafauna.BiomeTilesFile: &fstest.MapFile{Data: []byte(
+			"MA16,TRUE,A,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,TRUE,FALSE,FALSE,FALSE\n" +
+				"MA20,TRUE,A,20,L,Cordaites,Broadleaf Conifer Forest,BB,,size,FALSE,FALSE,FALSE,FALSE,FALSE\n"),
+		},
+	}
+	_, err := megafauna.InitFS(fsys)
+	e, ok := err.(*megafauna.DataError)
+	if !ok || e.File != megafauna.BiomeTilesFile || e.Line != 2 {
+		t.Errorf("Expected an error on line 2 of the biome tiles, got %v.", err)
+	}
+}
+
+func TestCardMapSource(t *testing.T) {
+	mutation := "M1,1,6,S,,Breathing while running,Carrier's Constant Diaphragm,,T,,,,"
+	genotype := "G2,cat,Pholidota,Pangolins,,1,2,IA,fin,Crurotarsi,Aetosaurs,,1,3,AN,T,,,,"
+	cards := make(megafauna.CardMap)
+	err := cards.Source(megafauna.MutationCardsFile).Parse(strings.NewReader(mutation))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err = cards.Source(megafauna.GenotypeCardsFile).Parse(strings.NewReader(genotype))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if cards["M1"].Mutation == nil || cards["G2"].Genotype == nil {
+		t.Error("The card sources didn't parse mutation and genotype cards.")
+	}
+	if cards.Source(megafauna.BiomeTilesFile) != nil {
+		t.Error("Expected no card source for the biome tiles.")
+	}
+
+	// genotype data in the mutation cards source is an error, not genotype cards
+	_, err = megafauna.Init(map[string]io.Reader{megafauna.MutationCardsFile: strings.NewReader(genotype)})
+	e, ok := err.(*megafauna.DataError)
+	if !ok || e.File != megafauna.MutationCardsFile || e.Line != 1 {
+		t.Errorf("Expected an error on line 1 of the mutation cards, got %v.", err)
+	}
+}
+
+func TestInit_Board(t *testing.T) {
+	sources := map[string]io.Reader{
+		megafauna.BoardFile: strings.NewReader("A0,A,2,FALSE,,A1,,\nA1,A,1,FALSE,,,,A0\nT0,T,3,TRUE,,,,"),
+	}
+	rules, err := megafauna.Init(sources)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	g, err := megafauna.NewGameWithRules(rules, []string{"Tinker", "Evers"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(g.Board.HabitatMap) != 3 || len(g.Board.LatitudeMap["T"].Habitats) != 1 {
+		t.Error("The game's board should come from the RuleSet.")
+	}
+
+	sources[megafauna.BoardFile] = strings.NewReader("A0,A,2,FALSE,,A1,,\nA1,A,1,FALSE,,,,")
+	_, err = megafauna.Init(sources)
+	if err == nil {
+		t.Error("Expected an error for an asymmetric board layout.")
+	}
+}
@@ -0,0 +1,200 @@
+package megafauna_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"megafauna"
+	"megafauna/server"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// eventStream reads server-sent events from a game's event stream.
+type eventStream struct {
+	r      *bufio.Reader
+	cancel context.CancelFunc
+}
+
+func openEventStream(t *testing.T, ts *httptest.Server, gameID, since string) *eventStream {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%v/games/%v/events", ts.URL, gameID), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if since != "" {
+		req.Header.Set("Last-Event-ID", since)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200 for the event stream, got %v", resp.StatusCode)
+	}
+	return &eventStream{r: bufio.NewReader(resp.Body), cancel: cancel}
+}
+
+// next reads the next event from the stream.
+func (s *eventStream) next(t *testing.T) *server.Event {
+	t.Helper()
+	var e server.Event
+	var id, eventType string
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if e.Type != eventType || fmt.Sprint(e.Version) != id {
+				t.Fatalf("The event's id and type (%v, %v) don't match its data (%v, %v).", id, eventType, e.Version, e.Type)
+			}
+			return &e
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestServer_Events(t *testing.T) {
+	ts := httptest.NewServer(server.New())
+	defer ts.Close()
+
+	var g gameResponse
+	do(t, ts, "POST", "/games", map[string][]string{"Players": {"A", "B"}}, http.StatusCreated, &g)
+
+	stream := openEventStream(t, ts, g.ID, "")
+	defer stream.cancel()
+	e := stream.next(t)
+	if e.Type != server.EventSnapshot || e.Version != 0 {
+		t.Fatalf("Expected a Snapshot at version 0, got %v at version %v", e.Type, e.Version)
+	}
+
+	token := join(t, ts, g.ID, g.ActivePlayer)
+	pass := &megafauna.Action{Type: megafauna.ActionPass, Dentition: g.ActivePlayer, Silhouette: -1}
+	doAs(t, ts, token, "POST", fmt.Sprintf("/games/%v/actions", g.ID), pass, http.StatusOK, nil)
+	e = stream.next(t)
+	if e.Type != server.EventActionTaken || e.Version != 1 || e.Action.Dentition != g.ActivePlayer {
+		t.Fatalf("Expected the pass to be announced at version 1, got %+v", e)
+	}
+
+	// buying a card passes on the game's own events
+	other := g.Players[0].Dentition
+	if other == g.ActivePlayer {
+		other = g.Players[1].Dentition
+	}
+	otherToken := join(t, ts, g.ID, other)
+	var actions []*megafauna.Action
+	doAs(t, ts, otherToken, "GET", fmt.Sprintf("/games/%v/players/%v/actions", g.ID, other), nil, http.StatusOK, &actions)
+	var buy *megafauna.Action
+	for _, a := range actions {
+		if a.Type == megafauna.ActionBuyCard {
+			buy = a
+			break
+		}
+	}
+	if buy == nil {
+		t.Fatal("Expected to be able to buy a card.")
+	}
+	doAs(t, ts, otherToken, "POST", fmt.Sprintf("/games/%v/actions", g.ID), buy, http.StatusOK, nil)
+	if e = stream.next(t); e.Type != server.EventActionTaken || e.Version != 2 {
+		t.Fatalf("Expected the purchase to be announced at version 2, got %v at version %v", e.Type, e.Version)
+	}
+	for _, expected := range []megafauna.GameEventType{megafauna.EventCardBought, megafauna.EventCardDrawn, megafauna.EventTilePlaced} {
+		e = stream.next(t)
+		for e.Type != string(expected) {
+			e = stream.next(t)
+		}
+		if e.Version != 2 || e.GameEvent == nil {
+			t.Fatalf("Expected a %v event at version 2, got %+v", expected, e)
+		}
+	}
+	if e.TileKey == "" || e.HabitatKey == "" {
+		t.Errorf("Expected the TilePlaced event to say which tile went where, got %+v", e.GameEvent)
+	}
+
+	// only the player can see their own view of the game
+	resp, err := http.Get(fmt.Sprintf("%v/games/%v/events?dentition=%v", ts.URL, g.ID, g.ActivePlayer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 for another player's event stream, got %v", resp.StatusCode)
+	}
+
+	// a seat token isn't accepted in the URL, but a stream token is
+	streamURL := fmt.Sprintf("%v/games/%v/events?dentition=%v&token=", ts.URL, g.ID, g.ActivePlayer)
+	resp, err = http.Get(streamURL + token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a seat token in the URL, got %v", resp.StatusCode)
+	}
+	doAs(t, ts, "", "POST", fmt.Sprintf("/games/%v/players/%v/stream", g.ID, g.ActivePlayer), nil, http.StatusForbidden, nil)
+	var streamToken struct {
+		Token   string
+		Expires time.Time
+	}
+	doAs(t, ts, token, "POST", fmt.Sprintf("/games/%v/players/%v/stream", g.ID, g.ActivePlayer), nil, http.StatusCreated, &streamToken)
+	if !streamToken.Expires.After(time.Now()) {
+		t.Errorf("Expected the stream token to expire in the future, got %v", streamToken.Expires)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", streamURL+streamToken.Token, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200 with a stream token, got %v", resp.StatusCode)
+	}
+	e = (&eventStream{r: bufio.NewReader(resp.Body), cancel: cancel}).next(t)
+	if e.Type != server.EventSnapshot || e.Game.Viewer != g.ActivePlayer {
+		t.Errorf("Expected a Snapshot for player %v, got %+v", g.ActivePlayer, e)
+	}
+	resp.Body.Close()
+	resp, err = http.Get(fmt.Sprintf("%v/games/%v/events?dentition=%v&token=%v", ts.URL, g.ID, other, streamToken.Token))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a stream token used for another player, got %v", resp.StatusCode)
+	}
+
+	// a client that reconnects gets what it missed, rather than a new snapshot
+	resumed := openEventStream(t, ts, g.ID, "0")
+	defer resumed.cancel()
+	e = resumed.next(t)
+	if e.Type != server.EventActionTaken || e.Version != 1 {
+		t.Errorf("Expected to resume with the pass at version 1, got %v at version %v", e.Type, e.Version)
+	}
+
+	// a client from the future has to resync
+	resynced := openEventStream(t, ts, g.ID, "99")
+	defer resynced.cancel()
+	e = resynced.next(t)
+	if e.Type != server.EventSnapshot || e.Version != 2 {
+		t.Errorf("Expected a Snapshot at version 2, got %v at version %v", e.Type, e.Version)
+	}
+}
@@ -0,0 +1,128 @@
+package megafauna_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"megafauna"
+	"megafauna/server"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// do sends a request to the server, checks its status, and decodes the response into v (if it's not nil).
+func do(t *testing.T, ts *httptest.Server, method, path string, body interface{}, status int, v interface{}) {
+	t.Helper()
+	doAs(t, ts, "", method, path, body, status, v)
+}
+
+// doAs is do, for the player whose seat token is given.
+func doAs(t *testing.T, ts *httptest.Server, token, method, path string, body interface{}, status int, v interface{}) {
+	t.Helper()
+	b, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(method, ts.URL+path, bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		t.Fatalf("%v %v: expected status %v, got %v", method, path, status, resp.StatusCode)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// join takes a player's seat in a game, and returns its token.
+func join(t *testing.T, ts *httptest.Server, gameID string, dentition int) string {
+	t.Helper()
+	var seat struct {
+		Dentition int
+		Token     string
+	}
+	do(t, ts, "POST", fmt.Sprintf("/games/%v/players/%v/join", gameID, dentition), nil, http.StatusCreated, &seat)
+	if seat.Dentition != dentition || seat.Token == "" {
+		t.Fatalf("Unexpected seat for player %v: %+v", dentition, seat)
+	}
+	return seat.Token
+}
+
+// gameResponse is the part of a game view that the tests look at.
+type gameResponse struct {
+	ID           string
+	Turn         int
+	ActivePlayer int
+	Players      []struct {
+		Dentition int
+		Genes     int
+	}
+}
+
+func TestServer(t *testing.T) {
+	ts := httptest.NewServer(server.New())
+	defer ts.Close()
+
+	do(t, ts, "POST", "/games", map[string][]string{"Players": {"A"}}, http.StatusBadRequest, nil)
+	do(t, ts, "GET", "/games/nosuchgame", nil, http.StatusNotFound, nil)
+
+	var g gameResponse
+	do(t, ts, "POST", "/games", map[string][]string{"Players": {"A", "B"}}, http.StatusCreated, &g)
+	if g.ID == "" || len(g.Players) != 2 {
+		t.Fatalf("Unexpected new game: %+v", g)
+	}
+	active := g.ActivePlayer
+	var other int
+	for _, p := range g.Players {
+		if p.Dentition != active {
+			other = p.Dentition
+		}
+	}
+
+	tokens := map[int]string{active: join(t, ts, g.ID, active), other: join(t, ts, g.ID, other)}
+	do(t, ts, "POST", fmt.Sprintf("/games/%v/players/%v/join", g.ID, active), nil, http.StatusConflict, nil)
+	do(t, ts, "POST", fmt.Sprintf("/games/%v/players/9/join", g.ID), nil, http.StatusNotFound, nil)
+
+	do(t, ts, "GET", fmt.Sprintf("/games/%v/players/%v", g.ID, active), nil, http.StatusForbidden, nil)
+	doAs(t, ts, tokens[other], "GET", fmt.Sprintf("/games/%v/players/%v", g.ID, active), nil, http.StatusForbidden, nil)
+	doAs(t, ts, tokens[active], "GET", fmt.Sprintf("/games/%v/players/%v", g.ID, active), nil, http.StatusOK, nil)
+	doAs(t, ts, tokens[active], "GET", fmt.Sprintf("/games/%v/players/9", g.ID), nil, http.StatusNotFound, nil)
+
+	var actions []*megafauna.Action
+	doAs(t, ts, tokens[other], "GET", fmt.Sprintf("/games/%v/players/%v/actions", g.ID, other), nil, http.StatusOK, &actions)
+	if len(actions) != 0 {
+		t.Errorf("The inactive player has %v legal actions.", len(actions))
+	}
+	doAs(t, ts, tokens[other], "GET", fmt.Sprintf("/games/%v/players/%v/actions", g.ID, active), nil, http.StatusForbidden, nil)
+	doAs(t, ts, tokens[active], "GET", fmt.Sprintf("/games/%v/players/%v/actions", g.ID, active), nil, http.StatusOK, &actions)
+	if len(actions) == 0 || actions[0].Type != megafauna.ActionPass {
+		t.Fatalf("Expected the first legal action to be Pass, got %+v", actions)
+	}
+
+	path := fmt.Sprintf("/games/%v/actions", g.ID)
+	doAs(t, ts, tokens[other], "POST", path, &megafauna.Action{Type: megafauna.ActionPass, Dentition: other, Silhouette: -1}, http.StatusConflict, nil)
+	doAs(t, ts, tokens[active], "POST", path, &megafauna.Action{Type: megafauna.ActionBuyCard, Dentition: active, CardKey: "nosuchcard"}, http.StatusNotFound, nil)
+	doAs(t, ts, tokens[active], "POST", path, &megafauna.Action{Type: megafauna.ActionPopulate, Dentition: active, Silhouette: 3}, http.StatusUnprocessableEntity, nil)
+	doAs(t, ts, tokens[active], "POST", path, "not an action", http.StatusBadRequest, nil)
+
+	// nobody can act for a player without their token
+	do(t, ts, "POST", path, actions[0], http.StatusForbidden, nil)
+	doAs(t, ts, tokens[other], "POST", path, actions[0], http.StatusForbidden, nil)
+
+	doAs(t, ts, tokens[active], "POST", path, actions[0], http.StatusOK, &g)
+	if g.Turn != 1 || g.ActivePlayer != other {
+		t.Errorf("Expected turn 1 with player %v active, got %+v", other, g)
+	}
+}
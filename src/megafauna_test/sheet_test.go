@@ -0,0 +1,82 @@
+package megafauna_test
+
+import (
+	"bytes"
+	"megafauna"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestRenderTileSVG(t *testing.T) {
+	tiles, err := megafauna.GetTiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tile := range tiles {
+		var b bytes.Buffer
+		if err := megafauna.RenderTileSVG(tile, &b); err != nil {
+			t.Fatal(err)
+		}
+		checkXML(t, b.Bytes())
+	}
+}
+
+func TestRenderSheets(t *testing.T) {
+	cardMap, err := megafauna.GetCards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cards := make([]*megafauna.Card, 0)
+	for _, c := range cardMap {
+		cards = append(cards, c)
+	}
+	tileMap, err := megafauna.GetTiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tiles := make([]*megafauna.Tile, 0)
+	for _, tile := range tileMap {
+		tiles = append(tiles, tile)
+	}
+
+	var b bytes.Buffer
+	if err := megafauna.RenderCardSheetSVG(cards[:megafauna.CardsPerSheet], &b); err != nil {
+		t.Fatal(err)
+	}
+	if counts := checkXML(t, b.Bytes()); counts["g"] != megafauna.CardsPerSheet {
+		t.Errorf("Expected %v cards on the sheet, got %v", megafauna.CardsPerSheet, counts["g"])
+	}
+	checkMargins(t, b.Bytes(), 250, 350)
+	if err := megafauna.RenderCardSheetSVG(cards[:megafauna.CardsPerSheet+1], &b); err != megafauna.ErrSheetFull {
+		t.Errorf("Expected ErrSheetFull, got %v", err)
+	}
+
+	b.Reset()
+	if err := megafauna.RenderTileSheetSVG(tiles[:megafauna.TilesPerSheet], &b); err != nil {
+		t.Fatal(err)
+	}
+	if counts := checkXML(t, b.Bytes()); counts["g"] != megafauna.TilesPerSheet {
+		t.Errorf("Expected %v tiles on the sheet, got %v", megafauna.TilesPerSheet, counts["g"])
+	}
+	checkMargins(t, b.Bytes(), 200, 200)
+	if err := megafauna.RenderTileSheetSVG(tiles[:megafauna.TilesPerSheet+1], &b); err != megafauna.ErrSheetFull {
+		t.Errorf("Expected ErrSheetFull, got %v", err)
+	}
+}
+
+// svgTranslate matches where a component is drawn on a sheet.
+var svgTranslate = regexp.MustCompile(`<g transform="translate\((\d+),(\d+)\)">`)
+
+// checkMargins checks that every component on a sheet, each width by height, is inside the sheet's half-inch
+// margin.  A sheet is 8.5 by 11 inches, at 100 units to the inch.
+func checkMargins(t *testing.T, sheet []byte, width, height int) {
+	t.Helper()
+	for _, m := range svgTranslate.FindAllSubmatch(sheet, -1) {
+		x, _ := strconv.Atoi(string(m[1]))
+		y, _ := strconv.Atoi(string(m[2]))
+		if x < 50 || y < 50 || x+width > 800 || y+height > 1050 {
+			t.Errorf("A component at %v, %v is outside the margin.", x, y)
+		}
+	}
+}
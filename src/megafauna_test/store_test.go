@@ -0,0 +1,355 @@
+package megafauna_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"megafauna"
+	"megafauna/server"
+	"megafauna/store"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// playRandomly applies random legal actions to a game.
+func playRandomly(g *megafauna.Game, r *rand.Rand, actions int) {
+	for i := 0; i < actions && !g.IsOver; i++ {
+		legal := g.LegalActions(g.GetActivePlayer().Dentition)
+		g.Apply(legal[r.Intn(len(legal))])
+	}
+}
+
+func TestReplay(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := megafauna.NewGameWithRules(rules, []string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(2)), 60)
+
+	replayed, err := megafauna.Replay(rules, g.Setup, g.Actions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g.ViewFor(0), replayed.ViewFor(0)) {
+		t.Error("The replayed game is different from the original.")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := store.NewFileStore(t.TempDir(), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, st, rules)
+}
+
+func TestSQLStore(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open(memSQLDriverName, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := store.NewSQLStore(db, "games; DROP TABLE games", rules); err != store.ErrInvalidID {
+		t.Errorf("Expected ErrInvalidID for a bad table name, got %v", err)
+	}
+	st, err := store.NewSQLStore(db, "games", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, st, rules)
+}
+
+// testStore checks that a Store saves, lists, loads and deletes games, and rejects invalid IDs.
+func testStore(t *testing.T, st store.Store, rules *megafauna.RuleSet) {
+	g, err := megafauna.NewGameWithRules(rules, []string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	playRandomly(g, rand.New(rand.NewSource(3)), 40)
+	seats := store.Seats{g.Players[0].Dentition: "hash"}
+	for _, id := range []string{"second", "first"} {
+		if err := st.Save(id, g, seats); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.Save("../escape", g, seats); err != store.ErrInvalidID {
+		t.Errorf("Expected ErrInvalidID saving, got %v", err)
+	}
+	if _, _, err := st.Load("../escape"); err != store.ErrInvalidID {
+		t.Errorf("Expected ErrInvalidID loading, got %v", err)
+	}
+	if err := st.Delete("../escape"); err != store.ErrInvalidID {
+		t.Errorf("Expected ErrInvalidID deleting, got %v", err)
+	}
+
+	ids, err := st.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"first", "second"}) {
+		t.Errorf("Expected [first second], got %v", ids)
+	}
+
+	loaded, loadedSeats, err := st.Load("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g.ViewFor(0), loaded.ViewFor(0)) {
+		t.Error("The loaded game is different from the saved one.")
+	}
+	if !reflect.DeepEqual(seats, loadedSeats) {
+		t.Errorf("Expected the seats %v, got %v", seats, loadedSeats)
+	}
+
+	// saving again replaces the game and its seats
+	playRandomly(g, rand.New(rand.NewSource(4)), 10)
+	seats[g.Players[1].Dentition] = "another hash"
+	if err := st.Save("first", g, seats); err != nil {
+		t.Fatal(err)
+	}
+	loaded, loadedSeats, err = st.Load("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(g.ViewFor(0), loaded.ViewFor(0)) {
+		t.Error("The game wasn't replaced when it was saved again.")
+	}
+	if !reflect.DeepEqual(seats, loadedSeats) {
+		t.Errorf("Expected the seats %v after saving again, got %v", seats, loadedSeats)
+	}
+
+	if err := st.Delete("first"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := st.Load("first"); err != store.ErrGameNotFound {
+		t.Errorf("Expected ErrGameNotFound after deleting, got %v", err)
+	}
+	if err := st.Delete("first"); err != store.ErrGameNotFound {
+		t.Errorf("Expected ErrGameNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestServer_Restart(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := store.NewFileStore(t.TempDir(), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := server.NewWithStore(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	var g gameResponse
+	do(t, ts, "POST", "/games", map[string][]string{"Players": {"A", "B"}}, http.StatusCreated, &g)
+	token := join(t, ts, g.ID, g.ActivePlayer)
+	pass := &megafauna.Action{Type: megafauna.ActionPass, Dentition: g.ActivePlayer, Silhouette: -1}
+	doAs(t, ts, token, "POST", "/games/"+g.ID+"/actions", pass, http.StatusOK, nil)
+	ts.Close()
+
+	s, err = server.NewWithStore(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts = httptest.NewServer(s)
+	defer ts.Close()
+	var restarted gameResponse
+	do(t, ts, "GET", "/games/"+g.ID, nil, http.StatusOK, &restarted)
+	if restarted.Turn != 1 || restarted.ActivePlayer == g.ActivePlayer {
+		t.Errorf("The game didn't survive the restart: %+v", restarted)
+	}
+
+	// the player keeps their seat, and nobody else can take it
+	doAs(t, ts, "", "POST", fmt.Sprintf("/games/%v/players/%v/join", g.ID, g.ActivePlayer), nil, http.StatusConflict, nil)
+	doAs(t, ts, token, "GET", fmt.Sprintf("/games/%v/players/%v", g.ID, g.ActivePlayer), nil, http.StatusOK, nil)
+}
+
+// failingStore is a Store whose Save fails when it's told to.
+type failingStore struct {
+	store.Store
+	fail bool
+}
+
+func (s *failingStore) Save(id string, g *megafauna.Game, seats store.Seats) error {
+	if s.fail {
+		return errors.New("The disk is full.")
+	}
+	return s.Store.Save(id, g, seats)
+}
+
+func TestServer_SaveFails(t *testing.T) {
+	rules, err := megafauna.Init(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := store.NewFileStore(t.TempDir(), rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := &failingStore{Store: fs}
+	s, err := server.NewWithStore(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	var g gameResponse
+	do(t, ts, "POST", "/games", map[string][]string{"Players": {"A", "B"}}, http.StatusCreated, &g)
+	token := join(t, ts, g.ID, g.ActivePlayer)
+
+	// a move that can't be saved isn't made
+	st.fail = true
+	pass := &megafauna.Action{Type: megafauna.ActionPass, Dentition: g.ActivePlayer, Silhouette: -1}
+	doAs(t, ts, token, "POST", "/games/"+g.ID+"/actions", pass, http.StatusInternalServerError, nil)
+	var after gameResponse
+	do(t, ts, "GET", "/games/"+g.ID, nil, http.StatusOK, &after)
+	if after.Turn != 0 || after.ActivePlayer != g.ActivePlayer {
+		t.Errorf("The move was made even though it couldn't be saved: %+v", after)
+	}
+
+	st.fail = false
+	doAs(t, ts, token, "POST", "/games/"+g.ID+"/actions", pass, http.StatusOK, &after)
+	if after.Turn != 1 {
+		t.Errorf("Expected the move to be made once it could be saved, got %+v", after)
+	}
+}
+
+// memSQLDriverName is the name of memSQLDriver, a database/sql driver that keeps a table of games in memory.
+// It only understands the statements that SQLStore uses, and its transactions don't roll anything back, but
+// that's enough to test SQLStore without a real database.  Connections with the same name share a table.
+const memSQLDriverName = "megafauna-memsql"
+
+func init() {
+	sql.Register(memSQLDriverName, &memSQLDriver{tables: make(map[string]*memSQLTable)})
+}
+
+type memSQLDriver struct {
+	mu     sync.Mutex
+	tables map[string]*memSQLTable
+}
+
+// memSQLTable is the games in a memSQL database, by ID; each row is the game's setup, actions and seats.
+type memSQLTable struct {
+	mu   sync.Mutex
+	rows map[string][3]string
+}
+
+func (d *memSQLDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.tables[name] == nil {
+		d.tables[name] = &memSQLTable{rows: make(map[string][3]string)}
+	}
+	return &memSQLConn{table: d.tables[name]}, nil
+}
+
+type memSQLConn struct {
+	table *memSQLTable
+}
+
+func (c *memSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &memSQLStmt{table: c.table, query: strings.Join(strings.Fields(query), " ")}, nil
+}
+
+func (c *memSQLConn) Close() error              { return nil }
+func (c *memSQLConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *memSQLConn) Commit() error             { return nil }
+func (c *memSQLConn) Rollback() error           { return nil }
+
+type memSQLStmt struct {
+	table *memSQLTable
+	query string
+}
+
+func (s *memSQLStmt) Close() error  { return nil }
+func (s *memSQLStmt) NumInput() int { return strings.Count(s.query, "?") }
+
+func (s *memSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE IF NOT EXISTS "):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "DELETE FROM ") && strings.HasSuffix(s.query, " WHERE id = ?"):
+		id := args[0].(string)
+		if _, ok := s.table.rows[id]; !ok {
+			return driver.RowsAffected(0), nil
+		}
+		delete(s.table.rows, id)
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(s.query, "INSERT INTO ") && strings.HasSuffix(s.query, " (id, setup, actions, seats) VALUES (?, ?, ?, ?)"):
+		id := args[0].(string)
+		if _, ok := s.table.rows[id]; ok {
+			return nil, errors.New("UNIQUE constraint failed")
+		}
+		s.table.rows[id] = [3]string{args[1].(string), args[2].(string), args[3].(string)}
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("memsql can't exec %q", s.query)
+}
+
+func (s *memSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+	switch {
+	case strings.HasPrefix(s.query, "SELECT setup, actions, seats FROM ") && strings.HasSuffix(s.query, " WHERE id = ?"):
+		rows := &memSQLRows{columns: []string{"setup", "actions", "seats"}}
+		if row, ok := s.table.rows[args[0].(string)]; ok {
+			rows.values = append(rows.values, []driver.Value{row[0], row[1], row[2]})
+		}
+		return rows, nil
+	case strings.HasPrefix(s.query, "SELECT id FROM ") && strings.HasSuffix(s.query, " ORDER BY id"):
+		ids := make([]string, 0)
+		for id := range s.table.rows {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		rows := &memSQLRows{columns: []string{"id"}}
+		for _, id := range ids {
+			rows.values = append(rows.values, []driver.Value{id})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("memsql can't query %q", s.query)
+}
+
+type memSQLRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *memSQLRows) Columns() []string { return r.columns }
+func (r *memSQLRows) Close() error      { return nil }
+
+func (r *memSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
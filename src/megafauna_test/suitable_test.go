@@ -0,0 +1,97 @@
+package megafauna_test
+
+import (
+	"megafauna"
+	"testing"
+)
+
+func TestSuitableBiomes(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C", "D"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := g.Board
+	biomes := board.Biomes()
+	omnivore := megafauna.MakeDNASpec("BBBBGGGGHHHHIIIIPPPPM")
+
+	// with no starting habitats, the whole board is in reach
+	all, err := board.SuitableBiomes(omnivore, 2, megafauna.SlotHerbivore, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(biomes) {
+		t.Fatalf("Expected an omnivore to be able to live in all %v biomes, got %v", len(biomes), len(all))
+	}
+
+	// from one biome, with no steps, only that biome is in reach
+	home := biomes[0]
+	near, err := board.SuitableBiomes(omnivore, 2, megafauna.SlotHerbivore, 0, home.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(near) != 1 || near[0].Biome != home || near[0].Steps != 0 {
+		t.Fatalf("Expected only %v to be in reach, got %v", home.Key, near)
+	}
+
+	// with enough steps, everything is, nearest first
+	far, err := board.SuitableBiomes(omnivore, 2, megafauna.SlotHerbivore, 100, home.Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(far) != len(biomes) || far[0].Biome != home {
+		t.Fatalf("Expected all %v biomes to be in reach from %v, got %v", len(biomes), home.Key, len(far))
+	}
+	for i := 1; i < len(far); i++ {
+		if far[i].Steps < far[i-1].Steps {
+			t.Errorf("Expected the biomes to be in order of steps, got %v after %v", far[i].Steps, far[i-1].Steps)
+		}
+		if far[i].Steps > 0 && far[i].Biome == home {
+			t.Errorf("Expected %v to be 0 steps away", home.Key)
+		}
+	}
+
+	// a species with no DNA can only live where there are no requirements, and never in the sea
+	none, err := board.SuitableBiomes(megafauna.MakeDNASpec(""), 2, megafauna.SlotHerbivore, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range none {
+		if s.Biome.IsSeaOnly() || !megafauna.MakeDNASpec("").CanFeedOn(s.Biome.Tile.BiomeData.Requirements) {
+			t.Errorf("A species with no DNA can't live in %v", s.Biome.Key)
+		}
+	}
+
+	// a predator needs prey of about its size that it could feed on in a cull
+	prey := &megafauna.Animal{Dentition: 3, Size: 3, Genome: megafauna.MakeDNASpec("BS")}
+	home.Herbivore = append(home.Herbivore, prey)
+	hunters, err := board.SuitableBiomes(megafauna.MakeDNASpec("BSM"), 2, megafauna.SlotPredator, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hunters) != 1 || hunters[0].Biome != home || len(hunters[0].Prey) != 1 || hunters[0].Prey[0] != prey {
+		t.Errorf("Expected a predator to find its prey in %v, got %v", home.Key, hunters)
+	}
+	if slow, _ := board.SuitableBiomes(megafauna.MakeDNASpec("BM"), 2, megafauna.SlotPredator, 0); len(slow) != 0 {
+		t.Errorf("Expected a predator without S to catch nothing, got %v", slow)
+	}
+	if small, _ := board.SuitableBiomes(megafauna.MakeDNASpec("BSM"), 1, megafauna.SlotPredator, 0); len(small) != 0 {
+		t.Errorf("Expected a predator two sizes smaller to catch nothing, got %v", small)
+	}
+
+	// a predator without B can catch the prey, but the cull won't let it feed on it
+	unfed := megafauna.MakeDNASpec("SM")
+	if !unfed.CanPreyOn(prey.Genome) {
+		t.Fatal("Expected a predator with S to be able to catch the prey.")
+	}
+	contest := &megafauna.CarnivoreContest{Carnivores: []*megafauna.Animal{{Dentition: 2, Size: 2, Genome: unfed}}, Prey: []*megafauna.Animal{prey}}
+	if winners := contest.FindWinners(); len(winners) != 0 {
+		t.Fatalf("Expected the predator to starve in a cull, got %v", winners)
+	}
+	if starving, _ := board.SuitableBiomes(unfed, 2, megafauna.SlotPredator, 0); len(starving) != 0 {
+		t.Errorf("Expected a predator that would starve in a cull to find no prey, got %v", starving)
+	}
+
+	if _, err := board.SuitableBiomes(omnivore, 2, megafauna.SlotHerbivore, 1, "X9"); err != megafauna.ErrHabitatNotFound {
+		t.Errorf("Expected ErrHabitatNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package megafauna_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"megafauna"
+	"strings"
+	"testing"
+)
+
+// checkXML makes sure that b is well-formed XML, and returns the number of each kind of element in it.
+func checkXML(t *testing.T, b []byte) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return counts
+		}
+		if err != nil {
+			t.Fatalf("The SVG isn't well-formed: %v", err)
+		}
+		if e, ok := tok.(xml.StartElement); ok {
+			counts[e.Name.Local]++
+		}
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := g.Players[0]
+	h := g.Board.FindLowestBiome(p.HomelandTile.LatitudeKey)
+	h.Biome.Herbivore = append(h.Biome.Herbivore, &megafauna.Animal{Dentition: p.Dentition, Silhouette: 1, Genome: megafauna.MakeDNASpec("")})
+
+	var b bytes.Buffer
+	if err := megafauna.RenderSVG(g, &b); err != nil {
+		t.Fatal(err)
+	}
+	counts := checkXML(t, b.Bytes())
+	if counts["svg"] != 1 || counts["g"] != len(g.Board.HabitatMap) {
+		t.Errorf("Expected one svg with a group for each of the %v habitats, got %v", len(g.Board.HabitatMap), counts)
+	}
+	silhouette := megafauna.MammalSilhouettes[1]
+	if p.IsDinosaur {
+		silhouette = megafauna.DinosaurSilhouettes[1]
+	}
+	for _, s := range []string{">" + silhouette + "<", ">" + h.Biome.Tile.Title + "<"} {
+		if !strings.Contains(b.String(), s) {
+			t.Errorf("Expected the SVG to contain %q", s)
+		}
+	}
+}
+
+func TestRenderCardSVG(t *testing.T) {
+	cards, err := megafauna.GetCards()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, c := range cards {
+		var b bytes.Buffer
+		if err := megafauna.RenderCardSVG(c, &b); err != nil {
+			t.Fatal(err)
+		}
+		checkXML(t, b.Bytes())
+		if !strings.Contains(b.String(), ">"+key+"<") {
+			t.Errorf("Card %v's SVG doesn't show its key.", key)
+		}
+	}
+}
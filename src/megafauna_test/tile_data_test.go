@@ -2,7 +2,9 @@ package megafauna_test
 
 import (
 	"megafauna"
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestGetTiles(t *testing.T) {
@@ -124,3 +126,47 @@ func TestGetInheritanceTiles(t *testing.T) {
 	}
 
 }
+
+func TestGetTilesFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		megafauna.ImmigrantTilesFile: &fstest.MapFile{
+			Data: []byte("M1,TRUE,A,TRUE,FALSE,2,HIIN,Primitive mammal,Multituberculates"),
+		},
+		megafauna.InheritanceTilesFile: &fstest.MapFile{
+			Data: []byte("1,2,H,1,4,B"),
+		},
+	}
+	tiles, err := megafauna.GetTilesFS(fsys)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if tiles["MH43"] == nil {
+		t.Error("Expected the embedded biome tiles to be used.")
+	}
+	if tiles["M2"] != nil || tiles["M1"] == nil || tiles["M1"].ImmigrantData.Size != 2 {
+		t.Error("Expected the immigrant tiles to come from the errata file.")
+	}
+
+	inheritance, err := megafauna.GetInheritanceTilesFS(fsys)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(inheritance) != 1 {
+		t.Errorf("Expected one inheritance tile, got %v.", len(inheritance))
+	}
+}
+
+func TestGetTilesFrom(t *testing.T) {
+	biomes := strings.NewReader("MA16,TRUE,A,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,TRUE,FALSE,FALSE,FALSE")
+	immigrants := strings.NewReader("")
+	tiles, err := megafauna.GetTilesFrom(biomes, immigrants)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(tiles) != 1 || tiles["MA16"] == nil {
+		t.Errorf("Expected only tile MA16, got %v.", tiles)
+	}
+}
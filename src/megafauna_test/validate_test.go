@@ -0,0 +1,115 @@
+package megafauna_test
+
+import (
+	"errors"
+	"megafauna"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidateData(t *testing.T) {
+	err := megafauna.ValidateData()
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateDataFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		megafauna.MutationCardsFile: &fstest.MapFile{Data: []byte(
+			"M1,1,6,S,,Breathing while running,Carrier's Constant Diaphragm,,T,,,,\n" +
+				"M2,5,4,SS,,Unidirectional respiration,Flow-Through Lungs,,C,Asteroid impact global cooling,5,FALSE,\n" +
+				"M1,1,4,PP,,Homoiotherm,Feathers,,T,,,,\n" +
+				"M4,1,5,B,M,Pubic bone shift,Biped Stance,,MP,,,,HX\n"),
+		},
+		megafauna.BiomeTilesFile: &fstest.MapFile{Data: []byte(
+			"MA16,TRUE,Q,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,TRUE,FALSE,FALSE,FALSE\n" +
+				"MA20,TRUE,A,20,L,Cordaites,Broadleaf Conifer Forest,BB,,size,maybe,FALSE,FALSE,FALSE\n" +
+				"MA30,TRUE,A,30,L,Gingkophytes,Ginkgo Woodland,B,,P,TRUE,FALSE,FALSE\n"),
+		},
+		megafauna.ImmigrantTilesFile: &fstest.MapFile{Data: []byte(
+			"M1,TRUE,A,TRUE,FALSE,1,HIXN,Primitive mammal,Multituberculates\n"),
+		},
+	}
+
+	err := megafauna.ValidateDataFS(fsys)
+	report, ok := err.(megafauna.ValidationReport)
+	if !ok {
+		t.Errorf("Expected a ValidationReport, got %v.", err)
+		return
+	}
+
+	expected := []struct {
+		file  string
+		line  int
+		field string
+	}{
+		{megafauna.MutationCardsFile, 2, "MaxSize"},
+		{megafauna.MutationCardsFile, 3, "Key"},
+		{megafauna.MutationCardsFile, 4, "MilankovichLatitudes"},
+		{megafauna.BiomeTilesFile, 1, "LatitudeKey"},
+		{megafauna.BiomeTilesFile, 2, "RedStar"},
+		{megafauna.BiomeTilesFile, 3, ""},
+		{megafauna.ImmigrantTilesFile, 1, "DNA"},
+	}
+	if len(report) != len(expected) {
+		t.Errorf("Expected %v problems, got %v.", len(expected), report)
+		return
+	}
+	for i, e := range expected {
+		if report[i].File != e.file || report[i].Line != e.line || report[i].Field != e.field {
+			t.Errorf("Expected a problem in %v line %v (%v), got %v.", e.file, e.line, e.field, report[i])
+		}
+	}
+	if !errors.Is(report[0], megafauna.ErrInvalidSizeRange) {
+		t.Errorf("Expected ErrInvalidSizeRange, got %v.", report[0].Err)
+	}
+	if !errors.Is(report[1], megafauna.ErrDuplicateKey) {
+		t.Errorf("Expected ErrDuplicateKey, got %v.", report[1].Err)
+	}
+	if !errors.Is(report[6], megafauna.ErrInvalidDNASpec) {
+		t.Errorf("Expected ErrInvalidDNASpec, got %v.", report[6].Err)
+	}
+}
+
+func TestGetTiles_ParseBoolError(t *testing.T) {
+	// a bad boolean used to make the parser silently drop the rest of the file
+	fsys := fstest.MapFS{
+		megafauna.BiomeTilesFile: &fstest.MapFile{Data: []byte(
+			"MA16,TRUE,A,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,yes please,FALSE,FALSE,FALSE\n"),
+		},
+	}
+	_, err := megafauna.GetTilesFS(fsys)
+	if err == nil {
+		t.Error("Expected an error for an invalid RedStar value.")
+	}
+}
+
+func TestDuplicateKeys(t *testing.T) {
+	fsys := fstest.MapFS{
+		megafauna.BiomeTilesFile: &fstest.MapFile{Data: []byte(
+			"MA16,TRUE,A,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,TRUE,FALSE,FALSE,FALSE\n" +
+				"MA16,TRUE,A,16,L,Deciduous Gymnosperm,Polar Forest,BB,H,N,TRUE,FALSE,FALSE,FALSE\n"),
+		},
+	}
+	if _, err := megafauna.GetTilesFS(fsys); !errors.Is(err, megafauna.ErrDuplicateKey) {
+		t.Errorf("Expected ErrDuplicateKey for a duplicate tile, got %v.", err)
+	}
+
+	cards := `[
+		{"key": "M1", "mutation": {"minSize": 1, "maxSize": 6, "mutation": "S", "title": "Diaphragm"}, "event": {"type": "drawTwo"}},
+		{"key": "M1", "mutation": {"minSize": 1, "maxSize": 6, "mutation": "S", "title": "Diaphragm"}, "event": {"type": "drawTwo"}}
+	]`
+	if _, err := megafauna.ParseCardsJSON(strings.NewReader(cards)); !errors.Is(err, megafauna.ErrDuplicateKey) {
+		t.Errorf("Expected ErrDuplicateKey for a duplicate JSON card, got %v.", err)
+	}
+
+	layout := make(megafauna.BoardLayout, 0)
+	if err := layout.Parse(strings.NewReader("X0,A,3,FALSE,,,,\nX0,A,3,FALSE,,,,\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := layout.NewBoard(); !errors.Is(err, megafauna.ErrDuplicateKey) {
+		t.Errorf("Expected ErrDuplicateKey for a duplicate habitat, got %v.", err)
+	}
+}
@@ -0,0 +1,45 @@
+package megafauna_test
+
+import (
+	"encoding/json"
+	"megafauna"
+	"strings"
+	"testing"
+)
+
+func TestViewFor(t *testing.T) {
+	g, err := megafauna.NewGame([]string{"A", "B", "C"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := g.Players[0]
+	v := g.ViewFor(p.Dentition)
+
+	if v.Viewer != p.Dentition || len(v.Players) != 3 {
+		t.Errorf("Unexpected view: %+v", v)
+	}
+	if v.TriassicCards != len(g.TriassicCardKeys) || v.MesozoicTiles != len(g.MesozoicTileKeys) || v.DeckCards != len(g.CardKeys) {
+		t.Error("The view's stack sizes don't match the game's.")
+	}
+	if len(v.Biomes) != len(g.Board.Biomes()) {
+		t.Errorf("Expected %v biomes in the view, got %v", len(g.Board.Biomes()), len(v.Biomes))
+	}
+
+	// the view mustn't give away what's in the stacks
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range append(g.TriassicCardKeys, g.CardKeys...) {
+		if strings.Contains(string(b), `"`+key+`"`) {
+			t.Errorf("The view gives away %v.", key)
+		}
+	}
+
+	// and it mustn't share anything with the game
+	v.UpperDisplay[0] = "changed"
+	v.Players[0].AnimalTokens[0] = 0
+	if g.UpperDisplayCardKeys[0] == "changed" || p.AnimalTokens[0] == 0 {
+		t.Error("Changing the view changed the game.")
+	}
+}